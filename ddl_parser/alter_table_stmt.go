@@ -16,6 +16,8 @@ type AlterTableStmt struct {
 //      4: Partition Modification Check
 //      5: Table Rename Check
 //      6: Alter Table Approach Check
+//      7: Other Alter Check
+//      8: TiDB Alter Table Check
 func (s *AlterTableStmt) Parse(r *ParseResult) {
 	r.SetOldTable(s.Table.Name.String())
 	for _, spec := range s.Specs {
@@ -49,6 +51,8 @@ func (s *AlterTableStmt) Parse(r *ParseResult) {
 		default:
 			s.checkOtherAlter(r, spec)
 		}
+
+		checkTiDBAlterSpecDef(r, spec) // Rule 8
 	}
 }
 
@@ -76,7 +80,7 @@ func (s *AlterTableStmt) checkModifyColumn(r *ParseResult, spec *ast.AlterTableS
 	}
 
 	// Reorder of column is not permitted
-	if pos := spec.Position; pos != nil {
+	if pos := spec.Position; pos != nil && len(spec.NewColumns) != 0 {
 		if pos.Tp == ast.ColumnPositionFirst {
 			r.AddError(ColReorderWithFirstErr.Accept(spec.NewColumns[0].Name.String()))
 		} else if pos.Tp == ast.ColumnPositionAfter {
@@ -93,7 +97,7 @@ func (s *AlterTableStmt) checkModifyColumn(r *ParseResult, spec *ast.AlterTableS
 		}
 	}
 
-	r.AddColumns(getAllColNames(spec.NewColumns))
+	r.AddColumns(getAllColumns(spec.NewColumns))
 }
 
 // Rule 3: Constraint Modification Check
@@ -149,8 +153,12 @@ func (s *AlterTableStmt) checkRenameTable(r *ParseResult, spec *ast.AlterTableSp
 }
 
 // Rule 6: Alter Table Approach Check
-//         no checking required
+//         `ALGORITHM=INSTANT` must be supported by the target
 func (s *AlterTableStmt) checkAlterApproach(r *ParseResult, spec *ast.AlterTableSpec) {
+	if spec.Tp == ast.AlterTableAlgorithm && spec.Algorithm == ast.AlgorithmTypeInstant &&
+		!r.Target.Supports(featureInstantDDL) {
+		r.AddError(UnsupportedFeatureErr.Accept(featureInstantDDL, r.Target, restoreClause(spec)))
+	}
 }
 
 // Rule 7: Other Alter Check
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"github.com/sidai/parser/dml"
+)
+
+// Approval: role whose approval is required before the statement is deployed, e.g. `DE` or `DBOps`
+type Approval struct {
+	Role   string `json:"role"`
+	Reason string `json:"reason"`
+	Rule   string `json:"rule"`
+}
+
+// validateApprovalRoles: the rule of the role mapping must be either built-in DDL or DML rule, or custom rule
+func validateApprovalRoles(roles map[string]string, rules []*CustomRule) error {
+	knownRules := make(map[string]struct{})
+	for _, rule := range DDLErrorRuleMap {
+		knownRules[rule] = struct{}{}
+	}
+	for _, rule := range dml.DMLErrorRuleMap {
+		knownRules[rule] = struct{}{}
+	}
+	for _, rule := range rules {
+		knownRules[rule.ID] = struct{}{}
+	}
+	for rule := range roles {
+		if _, ok := knownRules[rule]; !ok {
+			return fmt.Errorf("approval role is given to unknown rule `%s`", rule)
+		}
+	}
+	return nil
+}
+
+// applyApprovalRoles: override the role required by the rule, empty role means no approval is required
+func applyApprovalRoles(results []*ParseResult, roles map[string]string) {
+	for _, r := range results {
+		for _, err := range r.Error {
+			if role, ok := roles[err.Rule()]; ok {
+				err.approval = role
+			}
+		}
+	}
+}
+
+// getApproval: approval required by the finding, the finding waived is already approved by the waiver
+func getApproval(err *ReturnError) *Approval {
+	if err.Approval() == "" || err.Waiver() != nil {
+		return nil
+	}
+	return &Approval{
+		Role:   err.Approval(),
+		Reason: err.Error(),
+		Rule:   err.Rule(),
+	}
+}
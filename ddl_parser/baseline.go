@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+)
+
+// BaselineOptions: accept the existing findings recorded in the baseline file and report only the new ones
+// The baseline file is only written with `Update` or `Prune`, the run without them is read only
+type BaselineOptions struct {
+	File   string `json:"file"`
+	Update bool   `json:"update"` // Record the findings of the run into the baseline file, the other entries are kept
+	Prune  bool   `json:"prune"`  // Remove the entries not found in the run, the run should cover all the migrations
+}
+
+// Baseline: accepted findings keyed by statement fingerprint plus rule ID
+// The fingerprint is the `hash` of the statement fingerprint reported in the result, the baseline recorded with the
+// digest of `parser.NormalizeDigest` before does not match any more and should be recorded again with `Update`
+type Baseline struct {
+	Entries []*BaselineEntry `json:"entries"`
+}
+
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Rule        string `json:"rule"`
+	SQL         string `json:"sql"` // For review only, not used in matching
+}
+
+// LoadBaseline: load the baseline file, missing file is treated as empty baseline
+func LoadBaseline(file string) (*Baseline, error) {
+	content, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return &Baseline{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	baseline := &Baseline{}
+	if err := json.Unmarshal(content, baseline); err != nil {
+		return nil, err
+	}
+	return baseline, nil
+}
+
+// Save: write the entries sorted by fingerprint then rule to keep the file diff friendly
+func (b *Baseline) Save(file string) error {
+	sort.SliceStable(b.Entries, func(i, j int) bool {
+		if b.Entries[i].Fingerprint != b.Entries[j].Fingerprint {
+			return b.Entries[i].Fingerprint < b.Entries[j].Fingerprint
+		}
+		return b.Entries[i].Rule < b.Entries[j].Rule
+	})
+	content, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, append(content, '\n'), 0644)
+}
+
+func (b *Baseline) contains(fingerprint string, rule string) bool {
+	for _, entry := range b.Entries {
+		if entry.Fingerprint == fingerprint && entry.Rule == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// applyBaseline: move the findings accepted by the baseline to `Baselined`, then record or prune the baseline file if
+// asked
+func applyBaseline(results []*ParseResult, options *BaselineOptions) {
+	if len(results) == 0 {
+		return
+	}
+	baseline, err := LoadBaseline(options.File)
+	if err != nil {
+		results[0].AddError(InvalidOptionErr.Accept(err.Error()))
+		return
+	}
+
+	updated := &Baseline{}
+	for _, r := range results {
+		fingerprint := getFingerprint(r)
+		var errs []*ReturnError
+		for _, err := range r.Error {
+			if err.Level() == DDLMsgTypeIgnore || fingerprint == "" {
+				errs = append(errs, err)
+				continue
+			}
+			accepted := options.Update || baseline.contains(fingerprint, err.Rule())
+			if !accepted {
+				errs = append(errs, err)
+				continue
+			}
+			r.Baselined = append(r.Baselined, err)
+			if !updated.contains(fingerprint, err.Rule()) {
+				updated.Entries = append(updated.Entries, &BaselineEntry{
+					Fingerprint: fingerprint,
+					Rule:        err.Rule(),
+					SQL:         strings.TrimSpace(r.SQL),
+				})
+			}
+		}
+		r.Error = errs
+	}
+
+	if !options.Update && !options.Prune {
+		return
+	}
+	if !options.Prune {
+		for _, entry := range baseline.Entries {
+			if !updated.contains(entry.Fingerprint, entry.Rule) {
+				updated.Entries = append(updated.Entries, entry)
+			}
+		}
+	} else if !options.Update && len(updated.Entries) == len(baseline.Entries) {
+		// Entries matched are a subset of the baseline, nothing is pruned if the size is unchanged
+		return
+	}
+	if err := updated.Save(options.File); err != nil {
+		results[0].AddError(BaselineSaveErr.Accept(options.File, err.Error()))
+	}
+}
+
+// getFingerprint: hash of the statement fingerprint, insensitive to letter case, whitespace, comments and literals
+// The findings of the statement without fingerprint, e.g. syntax error, cannot be baselined
+func getFingerprint(r *ParseResult) string {
+	if r.Fingerprint == nil {
+		return ""
+	}
+	return r.Fingerprint.Hash
+}
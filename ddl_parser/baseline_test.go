@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestBaseline: record the findings, report only the new findings then prune the fixed entries only if asked
+func TestBaseline(t *testing.T) {
+	dir, err := ioutil.TempDir("", "baseline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "baseline.json")
+
+	legacy := "ALTER TABLE example ADD COLUMN code VARCHAR(64) NOT NULL AFTER name;\n" +
+		"ALTER TABLE example ADD COLUMN remark TEXT FIRST;\n"
+	results := parseWithOptions(legacy, fmt.Sprintf(`{"baseline": {"file": %q, "update": true}}`, file))
+	checkBaselineRun(t, results, 0, 3)
+	checkBaselineEntries(t, file, 3)
+
+	// Entries are keyed by the fingerprint reported in the result
+	baseline, err := LoadBaseline(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !baseline.contains(results[0].Fingerprint.Hash, DDLErrorRuleMap[ColReorderWithAfterErr]) {
+		t.Fatalf("fingerprint %s not found in %+v", results[0].Fingerprint.Hash, baseline.Entries)
+	}
+
+	// Reformatted statement keeps the fingerprint, the new statement is reported
+	changed := "alter table example\n  add column code varchar(64) not null after name;\n" +
+		"ALTER TABLE example ADD COLUMN remark TEXT FIRST;\n" +
+		"ALTER TABLE example ADD COLUMN seq BIGINT NOT NULL FIRST;\n"
+	results = parseWithOptions(changed, fmt.Sprintf(`{"baseline": {"file": %q}}`, file))
+	checkBaselineRun(t, results, 2, 3)
+	checkBaselineEntries(t, file, 3)
+
+	// Entries not found in the run are kept without `prune`, e.g. the run checking a single migration
+	fixed := "ALTER TABLE example ADD COLUMN remark TEXT FIRST;\n" +
+		"ALTER TABLE example ADD COLUMN seq BIGINT NOT NULL DEFAULT 0;\n"
+	results = parseWithOptions(fixed, fmt.Sprintf(`{"baseline": {"file": %q}}`, file))
+	checkBaselineRun(t, results, 0, 1)
+	checkBaselineEntries(t, file, 3)
+
+	// Recording the new findings keeps the other entries
+	results = parseWithOptions(changed, fmt.Sprintf(`{"baseline": {"file": %q, "update": true}}`, file))
+	checkBaselineRun(t, results, 0, 5)
+	checkBaselineEntries(t, file, 5)
+
+	// Entries of the statement fixed are pruned
+	results = parseWithOptions(fixed, fmt.Sprintf(`{"baseline": {"file": %q, "prune": true}}`, file))
+	checkBaselineRun(t, results, 0, 1)
+	checkBaselineEntries(t, file, 1)
+}
+
+func checkBaselineRun(t *testing.T, results []*ParseResult, findings int, baselined int) {
+	var actualFindings, actualBaselined int
+	for _, r := range results {
+		result := r.toReturnResult()
+		actualFindings += len(result.Findings)
+		actualBaselined += len(result.Baselined)
+	}
+	if actualFindings != findings || actualBaselined != baselined {
+		t.Fatalf("%d findings and %d baselined, expect %d and %d", actualFindings, actualBaselined, findings, baselined)
+	}
+}
+
+func checkBaselineEntries(t *testing.T, file string, entries int) {
+	baseline, err := LoadBaseline(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(baseline.Entries) != entries {
+		t.Fatalf("%d baseline entries, expect %d", len(baseline.Entries), entries)
+	}
+}
@@ -0,0 +1,528 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pingcap/parser"
+	"github.com/pingcap/parser/ast"
+	"github.com/sidai/parser/dml"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// Extension of the migration files replayed by `LoadCatalogFromDir`
+const migrationFileExt = ".sql"
+
+// Catalog: tables of the schema in creation order, built by replaying DDL statements
+type Catalog struct {
+	Tables []*Table `json:"tables"`
+
+	statements []string // Table DDL applied by `ApplySQL`, replayed to build the schema in dry run
+}
+
+func NewCatalog() *Catalog {
+	return &Catalog{}
+}
+
+// LoadCatalogFromDir: replay the `.sql` migration files under the directory in file name order
+func LoadCatalogFromDir(dir string) (*Catalog, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := NewCatalog()
+	for _, file := range files {
+		if file.IsDir() || strings.ToLower(filepath.Ext(file.Name())) != migrationFileExt {
+			continue
+		}
+		sql, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if err := catalog.ApplySQL(string(sql)); err != nil {
+			return nil, fmt.Errorf("%s: %s", file.Name(), err.Error())
+		}
+	}
+	return catalog, nil
+}
+
+// ApplySQL: apply all statements of the sql in order, statements other than table DDL are ignored
+func (c *Catalog) ApplySQL(sql string) error {
+	stmts, _, err := parser.New().Parse(sql, "", "")
+	if err != nil {
+		return SyntaxErr.Accept(err.Error())
+	}
+	for _, stmt := range stmts {
+		if err := c.Apply(stmt); err != nil {
+			return fmt.Errorf("%s: %s", err.Error(), strings.TrimSpace(stmt.Text()))
+		}
+		if isTableDDL(stmt) {
+			c.statements = append(c.statements, strings.TrimSpace(stmt.Text()))
+		}
+	}
+	return nil
+}
+
+func isTableDDL(stmt ast.StmtNode) bool {
+	switch stmt.(type) {
+	case *ast.CreateTableStmt, *ast.DropTableStmt, *ast.RenameTableStmt, *ast.AlterTableStmt, *ast.CreateIndexStmt,
+		*ast.DropIndexStmt:
+		return true
+	}
+	return false
+}
+
+// Apply: apply the statement to the catalog, statements other than table DDL are ignored
+func (c *Catalog) Apply(stmt ast.StmtNode) error {
+	switch s := stmt.(type) {
+	case *ast.CreateTableStmt:
+		return c.applyCreateTable(s)
+	case *ast.DropTableStmt:
+		return c.applyDropTable(s)
+	case *ast.RenameTableStmt:
+		for _, t2t := range s.TableToTables {
+			if err := c.renameTable(getTableName(t2t.OldTable), getTableName(t2t.NewTable)); err != nil {
+				return err
+			}
+		}
+	case *ast.AlterTableStmt:
+		return c.applyAlterTable(s)
+	case *ast.CreateIndexStmt:
+		table, err := c.mustGetTable(getTableName(s.Table))
+		if err != nil {
+			return err
+		}
+		con := &ast.Constraint{Tp: ast.ConstraintIndex, Name: s.IndexName, Keys: s.IndexPartSpecifications,
+			Option: s.IndexOption}
+		switch s.KeyType {
+		case ast.IndexKeyTypeUnique:
+			con.Tp = ast.ConstraintUniq
+		case ast.IndexKeyTypeSpatial:
+			con.Tp = ast.ConstraintSpatial
+		case ast.IndexKeyTypeFullText:
+			con.Tp = ast.ConstraintFulltext
+		}
+		table.Indexes = append(table.Indexes, buildIndexModel(con))
+	case *ast.DropIndexStmt:
+		table, err := c.mustGetTable(getTableName(s.Table))
+		if err != nil {
+			return err
+		}
+		if !table.dropIndex(s.IndexName) && !s.IfExists {
+			return fmt.Errorf("index `%s` does not exist in table `%s`", s.IndexName, table.Name)
+		}
+	}
+	return nil
+}
+
+// GetTable: return the table with the given name case insensitively, nil if not found
+func (c *Catalog) GetTable(tableName string) *Table {
+	for _, table := range c.Tables {
+		if strings.EqualFold(table.Name, tableName) {
+			return table
+		}
+	}
+	return nil
+}
+
+func (c *Catalog) mustGetTable(tableName string) (*Table, error) {
+	table := c.GetTable(tableName)
+	if table == nil {
+		return nil, fmt.Errorf("table `%s` does not exist", tableName)
+	}
+	return table, nil
+}
+
+func (c *Catalog) applyCreateTable(stmt *ast.CreateTableStmt) error {
+	tableName := getTableName(stmt.Table)
+	if c.GetTable(tableName) != nil {
+		if stmt.IfNotExists {
+			return nil
+		}
+		return fmt.Errorf("table `%s` already exists", tableName)
+	}
+
+	table := buildTableModel(stmt)
+	if stmt.ReferTable != nil {
+		referTable, err := c.mustGetTable(getTableName(stmt.ReferTable))
+		if err != nil {
+			return err
+		}
+		table = cloneTable(referTable)
+		table.Name = tableName
+	}
+	c.Tables = append(c.Tables, table)
+	return nil
+}
+
+func (c *Catalog) applyDropTable(stmt *ast.DropTableStmt) error {
+	if stmt.IsView {
+		return nil
+	}
+	for _, tableName := range stmt.Tables {
+		name := getTableName(tableName)
+		if c.GetTable(name) == nil {
+			if stmt.IfExists {
+				continue
+			}
+			return fmt.Errorf("table `%s` does not exist", name)
+		}
+		for i, table := range c.Tables {
+			if strings.EqualFold(table.Name, name) {
+				c.Tables = append(c.Tables[:i], c.Tables[i+1:]...)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Catalog) renameTable(oldName string, newName string) error {
+	table, err := c.mustGetTable(oldName)
+	if err != nil {
+		return err
+	}
+	if other := c.GetTable(newName); other != nil && other != table {
+		return fmt.Errorf("table `%s` already exists", newName)
+	}
+	table.Name = newName
+	return nil
+}
+
+func (c *Catalog) applyAlterTable(stmt *ast.AlterTableStmt) error {
+	table, err := c.mustGetTable(getTableName(stmt.Table))
+	if err != nil {
+		return err
+	}
+
+	for _, spec := range stmt.Specs {
+		switch spec.Tp {
+		case ast.AlterTableOption:
+			table.setOptions(buildTableOptionModels(spec.Options))
+
+		case ast.AlterTableAddColumns:
+			for i, col := range spec.NewColumns {
+				if table.GetColumn(col.Name.Name.String()) != nil {
+					if spec.IfNotExists {
+						continue
+					}
+					return fmt.Errorf("column `%s` already exists in table `%s`", col.Name.Name.String(), table.Name)
+				}
+				// Position only applies to the single added column
+				var pos *ast.ColumnPosition
+				if i == 0 {
+					pos = spec.Position
+				}
+				if err := table.insertColumn(buildColumnModel(col), pos); err != nil {
+					return err
+				}
+				table.Indexes = append(table.Indexes, buildInlineIndexModels(col)...)
+			}
+			for _, con := range spec.NewConstraints {
+				table.Indexes = append(table.Indexes, buildIndexModel(con))
+			}
+
+		case ast.AlterTableDropColumn:
+			colName := spec.OldColumnName.Name.String()
+			if table.GetColumn(colName) == nil {
+				if spec.IfExists {
+					continue
+				}
+				return fmt.Errorf("column `%s` does not exist in table `%s`", colName, table.Name)
+			}
+			table.dropColumn(colName)
+
+		case ast.AlterTableModifyColumn, ast.AlterTableChangeColumn:
+			if len(spec.NewColumns) == 0 {
+				continue
+			}
+			oldName := spec.NewColumns[0].Name.Name.String()
+			if spec.OldColumnName != nil {
+				oldName = spec.OldColumnName.Name.String()
+			}
+			if err := table.replaceColumn(oldName, spec.NewColumns[0], spec.Position); err != nil {
+				if spec.IfExists {
+					continue
+				}
+				return err
+			}
+
+		case ast.AlterTableRenameColumn:
+			col := table.GetColumn(spec.OldColumnName.Name.String())
+			if col == nil {
+				return fmt.Errorf("column `%s` does not exist in table `%s`", spec.OldColumnName.Name.String(), table.Name)
+			}
+			table.renameIndexColumn(col.Name, spec.NewColumnName.Name.String())
+			col.Name = spec.NewColumnName.Name.String()
+
+		case ast.AlterTableAlterColumn:
+			if len(spec.NewColumns) == 0 {
+				continue
+			}
+			col := table.GetColumn(spec.NewColumns[0].Name.Name.String())
+			if col == nil {
+				return fmt.Errorf("column `%s` does not exist in table `%s`", spec.NewColumns[0].Name.Name.String(), table.Name)
+			}
+			// `SET DEFAULT` carries the option of the default value without type while `DROP DEFAULT` carries none
+			col.Default = nil
+			for _, option := range spec.NewColumns[0].Options {
+				if option.Expr != nil {
+					col.Default = getRestoredPtr(option.Expr)
+				}
+			}
+
+		case ast.AlterTableAddConstraint:
+			index := buildIndexModel(spec.Constraint)
+			table.Indexes = append(table.Indexes, index)
+			// Columns of `PRIMARY KEY` are implicitly `NOT NULL`
+			if index.Type == IndexTypePrimary {
+				for _, indexCol := range index.Columns {
+					if col := table.GetColumn(indexCol.Name); col != nil {
+						col.Nullable = false
+					}
+				}
+			}
+
+		case ast.AlterTableDropPrimaryKey:
+			table.dropIndex(IndexTypePrimary)
+
+		case ast.AlterTableDropIndex, ast.AlterTableDropForeignKey, ast.AlterTableDropCheck:
+			if !table.dropIndex(spec.Name) && !spec.IfExists {
+				return fmt.Errorf("index `%s` does not exist in table `%s`", spec.Name, table.Name)
+			}
+
+		case ast.AlterTableRenameIndex:
+			index := table.getIndex(spec.FromKey.String())
+			if index == nil {
+				return fmt.Errorf("index `%s` does not exist in table `%s`", spec.FromKey.String(), table.Name)
+			}
+			index.Name = spec.ToKey.String()
+
+		case ast.AlterTableRenameTable:
+			if err := c.renameTable(table.Name, getTableName(spec.NewTable)); err != nil {
+				return err
+			}
+
+		case ast.AlterTablePartition:
+			table.Partition = buildPartitionModel(spec.Partition)
+
+		case ast.AlterTableRemovePartitioning:
+			table.Partition = nil
+
+		case ast.AlterTableAddPartitions:
+			if table.Partition == nil {
+				return fmt.Errorf("table `%s` is not partitioned", table.Name)
+			}
+			for _, def := range spec.PartDefinitions {
+				table.Partition.Definitions = append(table.Partition.Definitions, buildPartitionDefinitionModel(def))
+			}
+			if len(spec.PartDefinitions) == 0 {
+				table.Partition.Num += spec.Num
+			} else if table.Partition.Num > 0 {
+				table.Partition.Num = uint64(len(table.Partition.Definitions))
+			}
+
+		case ast.AlterTableCoalescePartitions:
+			if table.Partition != nil && table.Partition.Num > spec.Num {
+				table.Partition.Num -= spec.Num
+			}
+
+		case ast.AlterTableDropPartition:
+			if table.Partition == nil {
+				return fmt.Errorf("table `%s` is not partitioned", table.Name)
+			}
+			for _, partName := range spec.PartitionNames {
+				if !table.Partition.dropDefinition(partName.String()) && !spec.IfExists {
+					return fmt.Errorf("partition `%s` does not exist in table `%s`", partName.String(), table.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// insertColumn: add the column at the given position, append to the end if position is not specified
+func (t *Table) insertColumn(col *Column, pos *ast.ColumnPosition) error {
+	index := len(t.Columns)
+	if pos != nil {
+		switch pos.Tp {
+		case ast.ColumnPositionFirst:
+			index = 0
+		case ast.ColumnPositionAfter:
+			index = -1
+			for i, c := range t.Columns {
+				if strings.EqualFold(c.Name, pos.RelativeColumn.Name.String()) {
+					index = i + 1
+				}
+			}
+			if index < 0 {
+				return fmt.Errorf("column `%s` does not exist in table `%s`", pos.RelativeColumn.Name.String(), t.Name)
+			}
+		}
+	}
+
+	t.Columns = append(t.Columns, nil)
+	copy(t.Columns[index+1:], t.Columns[index:])
+	t.Columns[index] = col
+	return nil
+}
+
+// replaceColumn: replace the column by the new definition in place unless new position is specified
+func (t *Table) replaceColumn(oldName string, colDef *ast.ColumnDef, pos *ast.ColumnPosition) error {
+	index := -1
+	for i, col := range t.Columns {
+		if strings.EqualFold(col.Name, oldName) {
+			index = i
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("column `%s` does not exist in table `%s`", oldName, t.Name)
+	}
+
+	col := buildColumnModel(colDef)
+	t.renameIndexColumn(t.Columns[index].Name, col.Name)
+	t.Indexes = append(t.Indexes, buildInlineIndexModels(colDef)...)
+	if pos == nil || pos.Tp == ast.ColumnPositionNone {
+		t.Columns[index] = col
+		return nil
+	}
+	t.Columns = append(t.Columns[:index], t.Columns[index+1:]...)
+	return t.insertColumn(col, pos)
+}
+
+// dropColumn: remove the column and its key parts, index without any key part left is removed as well
+func (t *Table) dropColumn(colName string) {
+	var cols []*Column
+	for _, col := range t.Columns {
+		if !strings.EqualFold(col.Name, colName) {
+			cols = append(cols, col)
+		}
+	}
+	t.Columns = cols
+
+	var indexes []*Index
+	for _, index := range t.Indexes {
+		var indexCols []*IndexColumn
+		for _, indexCol := range index.Columns {
+			if !strings.EqualFold(indexCol.Name, colName) {
+				indexCols = append(indexCols, indexCol)
+			}
+		}
+		if len(indexCols) != 0 || len(index.Columns) == 0 {
+			index.Columns = indexCols
+			indexes = append(indexes, index)
+		}
+	}
+	t.Indexes = indexes
+}
+
+func (t *Table) renameIndexColumn(oldName string, newName string) {
+	for _, index := range t.Indexes {
+		for _, indexCol := range index.Columns {
+			if strings.EqualFold(indexCol.Name, oldName) {
+				indexCol.Name = newName
+			}
+		}
+	}
+}
+
+func (t *Table) getIndex(indexName string) *Index {
+	for _, index := range t.Indexes {
+		if strings.EqualFold(index.Name, indexName) {
+			return index
+		}
+	}
+	return nil
+}
+
+// dropIndex: remove the index with the given name, return false if not found
+func (t *Table) dropIndex(indexName string) bool {
+	for i, index := range t.Indexes {
+		if strings.EqualFold(index.Name, indexName) {
+			t.Indexes = append(t.Indexes[:i], t.Indexes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// setOptions: override the existing options with the same name, others are appended
+func (t *Table) setOptions(options []*TableOption) {
+	for _, option := range options {
+		found := false
+		for _, existing := range t.Options {
+			if existing.Name == option.Name {
+				existing.Value, found = option.Value, true
+			}
+		}
+		if !found {
+			t.Options = append(t.Options, option)
+		}
+	}
+}
+
+// dropDefinition: remove the partition with the given name, return false if not found
+func (p *Partition) dropDefinition(partName string) bool {
+	for i, def := range p.Definitions {
+		if strings.EqualFold(def.Name, partName) {
+			p.Definitions = append(p.Definitions[:i], p.Definitions[i+1:]...)
+			if p.Num > 0 {
+				p.Num--
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// clone: deep copy of the catalog, the copy of nil catalog is empty
+func (c *Catalog) clone() *Catalog {
+	cloned := NewCatalog()
+	if c != nil {
+		for _, table := range c.Tables {
+			cloned.Tables = append(cloned.Tables, cloneTable(table))
+		}
+		cloned.statements = append(cloned.statements, c.statements...)
+	}
+	return cloned
+}
+
+// getDMLOptions: the options with the schema of the tables in the catalog
+func (c *Catalog) getDMLOptions(options *dml.ParseOptions) *dml.ParseOptions {
+	withTables := *options
+	withTables.Tables = make(map[string]*dml.TableSchema)
+	for _, table := range c.Tables {
+		schema := &dml.TableSchema{}
+		for _, col := range table.Columns {
+			if _, ok := intTypeRankMap[col.Type]; ok {
+				schema.IntegerColumns = append(schema.IntegerColumns, col.Name)
+			}
+		}
+		for _, index := range table.Indexes {
+			switch index.Type {
+			case IndexTypePrimary:
+				for _, indexCol := range index.Columns {
+					schema.PrimaryKey = append(schema.PrimaryKey, indexCol.Name)
+				}
+			case IndexTypeUnique:
+				// The unique key without name is named after its first column
+				name := index.Name
+				if name == "" && len(index.Columns) != 0 {
+					name = index.Columns[0].Name
+				}
+				schema.UniqueKeys = append(schema.UniqueKeys, name)
+			}
+		}
+		withTables.Tables[strings.ToLower(table.Name)] = schema
+	}
+	return &withTables
+}
+
+func cloneTable(table *Table) *Table {
+	cloned := &Table{}
+	jsonStr, _ := json.Marshal(table)
+	_ = json.Unmarshal(jsonStr, cloned)
+	return cloned
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	_ "github.com/go-sql-driver/mysql"
+	"strings"
+)
+
+// LoadCatalogFromDB: build the catalog from the tables deployed in the database of the DSN
+// DSN is in the format of `github.com/go-sql-driver/mysql`, e.g. `user:password@tcp(127.0.0.1:3306)/shop`
+func LoadCatalogFromDB(dsn string) (*Catalog, error) {
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	return loadCatalogFromDB(db)
+}
+
+// loadCatalogFromDB: replay `SHOW CREATE TABLE` of the base tables listed in `information_schema` in creation order
+func loadCatalogFromDB(db *sql.DB) (*Catalog, error) {
+	var schemaName sql.NullString
+	if err := db.QueryRow("SELECT DATABASE()").Scan(&schemaName); err != nil {
+		return nil, err
+	}
+	if !schemaName.Valid || schemaName.String == "" {
+		return nil, fmt.Errorf("database must be specified in the DSN")
+	}
+
+	rows, err := db.Query("SELECT TABLE_NAME FROM information_schema.TABLES "+
+		"WHERE TABLE_SCHEMA = ? AND TABLE_TYPE = 'BASE TABLE' ORDER BY CREATE_TIME, TABLE_NAME", schemaName.String)
+	if err != nil {
+		return nil, err
+	}
+	var tableNames []string
+	for rows.Next() {
+		var tableName string
+		if err := rows.Scan(&tableName); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		tableNames = append(tableNames, tableName)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	catalog := NewCatalog()
+	for _, tableName := range tableNames {
+		var name, createTable string
+		err := db.QueryRow(fmt.Sprintf("SHOW CREATE TABLE %s.%s", quoteIdentifier(schemaName.String),
+			quoteIdentifier(tableName))).Scan(&name, &createTable)
+		if err != nil {
+			return nil, err
+		}
+		if err := catalog.ApplySQL(createTable); err != nil {
+			return nil, fmt.Errorf("%s: %s", tableName, err.Error())
+		}
+	}
+	return catalog, nil
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/tidb/config"
+	"github.com/pingcap/tidb/server"
+	"github.com/pingcap/tidb/session"
+	"github.com/pingcap/tidb/store/mockstore"
+	"go.uber.org/zap/zapcore"
+)
+
+// startMockServer: start TiDB on mock store as the stand-in MySQL server listening on unix socket
+func startMockServer(t *testing.T) (dsn string, stop func()) {
+	log.SetLevel(zapcore.FatalLevel)
+	store, err := mockstore.NewMockTikvStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dom, err := session.BootstrapSession(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "tidb")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.NewConfig()
+	cfg.Port = 0
+	cfg.Socket = filepath.Join(dir, "tidb.sock")
+	cfg.Status.ReportStatus = false
+	srv, err := server.NewServer(cfg, server.NewTiDBDriver(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+	go srv.Run()
+
+	return "root@unix(" + cfg.Socket + ")/test", func() {
+		srv.Close()
+		dom.Close()
+		store.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestLoadCatalogFromDB(t *testing.T) {
+	dsn, stop := startMockServer(t)
+	defer stop()
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for _, stmt := range []string{
+		"CREATE TABLE example (id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT, name VARCHAR(64) NOT NULL DEFAULT '', " +
+			"PRIMARY KEY (id)) COMMENT = 'example'",
+		"ALTER TABLE example ADD COLUMN code VARCHAR(32) NOT NULL DEFAULT ''",
+		"ALTER TABLE example ADD INDEX index_code (code)",
+		"CREATE TABLE example_order (id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT, PRIMARY KEY (id))",
+		"CREATE VIEW example_view AS SELECT id FROM example",
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	catalog, err := LoadCatalogFromDB(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(catalog.Tables) != 2 || catalog.GetTable("example_order") == nil {
+		t.Fatalf("unexpected tables %+v", catalog.Tables)
+	}
+	table := catalog.GetTable("example")
+	if code := table.GetColumn("code"); code == nil || code.ColumnType != "varchar(32)" {
+		t.Fatalf("unexpected column `code` %+v", code)
+	}
+	if table.getIndex("index_code") == nil || table.GetOption("comment") != "example" {
+		t.Fatalf("unexpected table %+v", table)
+	}
+
+	// Statements are validated against the deployed schema
+	results := parseWithOptions("ALTER TABLE example MODIFY COLUMN code VARCHAR(16) NOT NULL DEFAULT '';\n"+
+		"ALTER TABLE example DROP COLUMN remark;\n", `{"schema_dsn": "`+dsn+`"}`)
+	if !results[0].Risk.hasFactor(RiskFactorLossyTypeChange) {
+		t.Fatalf("lossy type change not found in %+v", results[0].Risk.Factors)
+	}
+	findings := results[1].toReturnResult().Findings
+	if len(findings) == 0 || findings[len(findings)-1].Rule != DDLErrorRuleMap[SchemaMismatchErr] {
+		t.Fatalf("schema mismatch not found in %+v", findings)
+	}
+}
+
+func TestLoadCatalogFromDBWithoutDatabase(t *testing.T) {
+	dsn, stop := startMockServer(t)
+	defer stop()
+
+	if _, err := LoadCatalogFromDB(dsn[:len(dsn)-len("test")]); err == nil {
+		t.Fatal("expect error without database")
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Replay cases of the catalog, each `.sql` case is compared with the `.json` golden file of the tables replayed
+const catalogGoldenDir = "testdata/catalog"
+
+type goldenCatalog struct {
+	Tables []*Table `json:"tables"`
+	Error  string   `json:"error,omitempty"` // Error stopping the replay, the tables are the ones replayed before it
+}
+
+func TestCatalogGolden(t *testing.T) {
+	for _, sqlFile := range getGoldenFiles(t, catalogGoldenDir) {
+		sqlFile := sqlFile
+		t.Run(strings.TrimSuffix(filepath.Base(sqlFile), ".sql"), func(t *testing.T) {
+			content, err := ioutil.ReadFile(sqlFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			catalog := NewCatalog()
+			result := &goldenCatalog{}
+			if err := catalog.ApplySQL(string(content)); err != nil {
+				result.Error = err.Error()
+			}
+			result.Tables = catalog.Tables
+
+			actual, err := json.MarshalIndent(result, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkGoldenFile(t, strings.TrimSuffix(sqlFile, ".sql")+".json", append(actual, '\n'))
+		})
+	}
+}
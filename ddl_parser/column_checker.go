@@ -43,7 +43,7 @@ func checkAllColDef(r *ParseResult, cols []*ast.ColumnDef, ddlType string) map[s
 }
 
 // Rule: column `id`
-//       `BIGINT UNSIGNED` type with `AUTO INCREMENT`
+//       `BIGINT UNSIGNED` type with `AUTO INCREMENT`, or `AUTO_RANDOM` if supported by the target
 func checkColIDDef(r *ParseResult, col *ast.ColumnDef) {
 	colInfo := col.Tp
 	if colInfo == nil || !mysql.HasUnsignedFlag(colInfo.Flag) { // UNSIGNED
@@ -57,6 +57,8 @@ func checkColIDDef(r *ParseResult, col *ast.ColumnDef) {
 	for _, option := range col.Options {
 		if option.Tp == ast.ColumnOptionAutoIncrement { // Auto Increment
 			autoIncSet = true
+		} else if option.Tp == ast.ColumnOptionAutoRandom && r.Target.Supports(featureAutoRandom) { // Auto Random
+			autoIncSet = true
 		}
 	}
 	if !autoIncSet {
@@ -123,9 +125,12 @@ func checkColUpdatedAtDef(r *ParseResult, col *ast.ColumnDef) {
 //    4: Don't specify a display length for BIGINT, INT, MEDIUMINT, SMALLINT, and TINYINT etc.
 //    5: Don't use non-null default value for JSON, TEXT, BLOB (LARGE OBJECT), SPATIAL data type
 //    6: Don't use ENUM fields for storing input
-//    7: Don't use reserved keyword for column name
+//    7: Don't use reserved keyword of the target for column name
 //    8: Don't use upper case for column name
 //    9: `NOT NULL` column must provided `DEFAULT` unless has `AUTO_INCREMENT` or violate RULE 5 (Warning) ALTER TABLE only
+//   10: `DEFAULT` value must be supported by the target
+//   11: `CHECK` should be enforced by the target (Warning)
+//   12: `AUTO_RANDOM` must be supported by the target
 func checkColDef(r *ParseResult, col *ast.ColumnDef, ddlType string) {
 	colInfo := col.Tp
 	colName := col.Name.Name.String()
@@ -133,7 +138,7 @@ func checkColDef(r *ParseResult, col *ast.ColumnDef, ddlType string) {
 		r.AddError(ColNameWithHyphenErr.Accept(colName))
 	}
 
-	if _, ok := reservedWords[strings.ToUpper(colName)]; ok { // Rule 7
+	if r.Target.IsReservedWord(colName) { // Rule 7
 		r.AddError(ColReservedWordErr.Accept(colName))
 	}
 	if strings.ToLower(colName) != colName { // Rule 8
@@ -149,10 +154,16 @@ func checkColDef(r *ParseResult, col *ast.ColumnDef, ddlType string) {
 			if expr, ok := option.Expr.(*driver.ValueExpr); ok {
 				defaultNull = expr.IsNull()
 			}
+			checkDefaultValueExpr(r, col, option.Expr) // Rule 10
+		} else if option.Tp == ast.ColumnOptionCheck { // Rule 11
+			checkCheckConstraintEnforced(r, option.Enforced, restoreClause(col))
 		} else if option.Tp == ast.ColumnOptionNotNull {
 			hasNotNull = true
 		} else if option.Tp == ast.ColumnOptionAutoIncrement {
 			autoIncSet = true
+		} else if option.Tp == ast.ColumnOptionAutoRandom { // Rule 12
+			checkColOptAutoRandomDef(r, col)
+			autoIncSet = true
 		}
 	}
 
@@ -168,7 +179,7 @@ func checkColDef(r *ParseResult, col *ast.ColumnDef, ddlType string) {
 			if colInfo.Tp != mysql.TypeTiny && colInfo.Flen != types.UnspecifiedLength {
 				r.AddError(ColDisplayLengthIntErr.Accept(colName))
 			}
-		} else if colInfo.Tp == mysql.TypeJSON || types.IsTypeGeometry(colInfo.Tp) || types.IsTypeBlob(colInfo.Tp) {
+		} else if colInfo.Tp == mysql.TypeJSON || colInfo.Tp == mysql.TypeGeometry || types.IsTypeBlob(colInfo.Tp) {
 			if hasDefault && !defaultNull { // Rule 5
 				r.AddError(ColNotNullDefaultErr.Accept(colName, types.TypeStr(colInfo.Tp)))
 			}
@@ -183,6 +194,27 @@ func checkColDef(r *ParseResult, col *ast.ColumnDef, ddlType string) {
 	}
 }
 
+// Rule: column `DEFAULT` value
+//       Expression or sequence as `DEFAULT` value must be supported by the target
+func checkDefaultValueExpr(r *ParseResult, col *ast.ColumnDef, expr ast.ExprNode) {
+	feature := ""
+	switch impl := expr.(type) {
+	case nil, *driver.ValueExpr, *ast.UnaryOperationExpr: // literal
+	case *ast.FuncCallExpr:
+		if strings.ToLower(impl.FnName.String()) == ast.NextVal {
+			feature = featureSequenceDefault
+		} else if !checkCurrTimestampExpr(impl) {
+			feature = featureExprDefault
+		}
+	default:
+		feature = featureExprDefault
+	}
+
+	if feature != "" && !r.Target.Supports(feature) {
+		r.AddError(UnsupportedFeatureErr.Accept(feature, r.Target, restoreClause(col)))
+	}
+}
+
 // return if the expr evaluates to `CURRENT_TIMESTAMP`
 func checkCurrTimestampExpr(expr ast.ExprNode) bool {
 	if expr == nil {
@@ -193,6 +225,17 @@ func checkCurrTimestampExpr(expr ast.ExprNode) bool {
 	return ok && strings.ToLower(funcCallExpr.FnName.String()) == ast.CurrentTimestamp
 }
 
+// Return all columns as structured model in declaration order
+func getAllColumns(cols []*ast.ColumnDef) []*Column {
+	var columns []*Column
+	for _, col := range cols {
+		if col.Tp != nil {
+			columns = append(columns, buildColumnModel(col))
+		}
+	}
+	return columns
+}
+
 func getAllColNames(cols []*ast.ColumnDef) map[string]string {
 	colNames := make(map[string]string)
 	for _, col := range cols {
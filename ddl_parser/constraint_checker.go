@@ -44,6 +44,8 @@ var (
 //     3: `DATETIME` or `TIMESTAMP` column in `COMPOSITE KEY` must appear at the end
 //     4: Don't use `FOREIGN KEY`
 //     5:  All columns used in CONSTRAINT must be declared in column list before
+//     6: Functional key part must be supported by the target
+//     7: `CHECK` should be enforced by the target (Warning)
 func checkAllConstraintDef(r *ParseResult, cons []*ast.Constraint, cols []*ast.ColumnDef, part *ast.PartitionOptions) map[string]*ast.Constraint {
 	conMap := make(map[string]*ast.Constraint)
 	for _, con := range cons {
@@ -73,7 +75,10 @@ func checkConstraintDef(r *ParseResult, con *ast.Constraint, colNames map[string
 		checkKeyDef(r, con, colNames)
 	case foreignKeyPrefix:
 		checkForeignKeyDef(r, con, colNames)
+	case checkPrefix:
+		checkCheckConstraintEnforced(r, con.Enforced, restoreClause(con))
 	}
+	checkFunctionalKeyDef(r, con)
 
 	if checker, ok := reqConCheck[conName]; ok {
 		checker(r, con)
@@ -105,6 +110,25 @@ func checkPrimaryKeyDef(r *ParseResult, con *ast.Constraint, colNames map[string
 	checkIndexRangeKeyDef(r, con, conUsed, colNames)
 }
 
+// Rule: functional key part
+//       must be supported by the target
+func checkFunctionalKeyDef(r *ParseResult, con *ast.Constraint) {
+	for _, key := range con.Keys {
+		if key.Expr != nil && !r.Target.Supports(featureFunctionalIndex) {
+			r.AddError(UnsupportedFeatureErr.Accept(featureFunctionalIndex, r.Target, restoreClause(con)))
+			return
+		}
+	}
+}
+
+// Rule: check constraint
+//       should be enforced by the target (Warning)
+func checkCheckConstraintEnforced(r *ParseResult, enforced bool, clause string) {
+	if enforced && !r.Target.Supports(featureCheckConstraint) {
+		r.AddError(CheckNotEnforcedErr.Accept(r.Target, clause))
+	}
+}
+
 // Rule: unique key
 //       must prefix with `uk_`
 //       must contains all columns used in `PARTITION KEY`
@@ -156,7 +180,8 @@ func checkIndexRangeKeyDef(r *ParseResult, con *ast.Constraint, conUsed map[stri
 // Rule: index `index_created_at`
 //       must be `INDEX/KEY` with column (`created_at`) only
 func checkIndexCreatedAtDef(r *ParseResult, con *ast.Constraint) {
-	if conTypeStringMap[con.Tp] != keyPrefix || len(con.Keys) != 1 || con.Keys[0].Column.Name.String() != columnCreatedAt {
+	if conTypeStringMap[con.Tp] != keyPrefix || len(con.Keys) != 1 || con.Keys[0].Column == nil ||
+		con.Keys[0].Column.Name.String() != columnCreatedAt {
 		r.AddError(KeyCreatedAtFormatErr)
 	}
 }
@@ -164,16 +189,19 @@ func checkIndexCreatedAtDef(r *ParseResult, con *ast.Constraint) {
 // Rule: index `index_updated_at`
 //       must be `INDEX/KEY` with column (`updated_at`) only
 func checkIndexUpdatedAtDef(r *ParseResult, con *ast.Constraint) {
-	if conTypeStringMap[con.Tp] != keyPrefix || len(con.Keys) != 1 || con.Keys[0].Column.Name.String() != columnUpdatedAt {
+	if conTypeStringMap[con.Tp] != keyPrefix || len(con.Keys) != 1 || con.Keys[0].Column == nil ||
+		con.Keys[0].Column.Name.String() != columnUpdatedAt {
 		r.AddError(KeyUpdatedAtFormatErr)
 	}
 }
 
-// Return all of the column used with index in the given constraint
+// Return all of the column used with index in the given constraint, functional key part is skipped
 func getAllUsedColumn(con *ast.Constraint) map[string]int {
 	colUsed := make(map[string]int)
 	for index, key := range con.Keys {
-		colUsed[key.Column.Name.String()] = index
+		if key.Column != nil {
+			colUsed[key.Column.Name.String()] = index
+		}
 	}
 	return colUsed
 }
@@ -182,7 +210,9 @@ func getAllUsedColumn(con *ast.Constraint) map[string]int {
 func formStandardConName(con *ast.Constraint) string {
 	tokens := []string{conTypeStringMap[con.Tp]}
 	for _, key := range con.Keys {
-		tokens = append(tokens, key.Column.Name.String())
+		if key.Column != nil {
+			tokens = append(tokens, key.Column.Name.String())
+		}
 	}
 	return strings.Join(tokens, NameSeparator)
 }
\ No newline at end of file
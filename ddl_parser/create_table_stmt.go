@@ -18,6 +18,7 @@ type CreateTableStmt struct {
 //      7: Table Option Definition Check
 //      8: Table Name Definition Check
 //      9: Table Partition Definition Check
+//     10: TiDB Table Definition Check
 func (s *CreateTableStmt) Parse(r *ParseResult) {
 	if s.IsTemporary { // Rule 1
 		r.AddError(TempTableErr)
@@ -40,8 +41,13 @@ func (s *CreateTableStmt) Parse(r *ParseResult) {
 	s.checkTableOptionsDef(r) // Rule 7
     s.checkTableNameDef(r) // Rule 8
 	s.checkTablePartitionDef(r) // Rule 9
+	s.checkTiDBTableDef(r) // Rule 10
 
 	r.AddDDLType(TypeCreateTable)
+
+	table := buildTableModel(s.CreateTableStmt)
+	r.SetTable(table)
+	r.AddColumns(table.Columns)
 }
 
 // Rule 5: Table Column Definition Check
@@ -58,8 +64,6 @@ func (s *CreateTableStmt) checkTableColumnsDef(r *ParseResult) {
 			r.AddError(err)
 		}
 	}
-
-	r.AddColumns(getAllColNames(s.Cols))
 }
 
 // Rule 6: Table Constraint Definition Check
@@ -116,4 +120,15 @@ func (s *CreateTableStmt) checkTableNameDef(r *ParseResult) {
 //      9.2: Don't use HASH partition (Warning)
 func (s *CreateTableStmt) checkTablePartitionDef(r *ParseResult) {
 	checkAllPartitionDef(r, s.Partition, s.Cols)
+}
+
+// Rule 10: TiDB Table Definition Check, only applied when the target is TiDB
+//      10.1: `AUTO_INCREMENT` on clustered `BIGINT` primary key creates write hotspot, use `AUTO_RANDOM` instead (Warning)
+//      10.2: `AUTO_RANDOM` column must be the clustered `BIGINT` primary key
+//      10.3: Table without clustered primary key should set `SHARD_ROW_ID_BITS` (Warning)
+//      10.4: `PRE_SPLIT_REGIONS` requires `SHARD_ROW_ID_BITS` or `AUTO_RANDOM` and must not exceed `SHARD_ROW_ID_BITS`
+//      10.5: Partition type must be supported by TiDB
+//      10.6: Spatial column, spatial index and full text index are not supported by TiDB
+func (s *CreateTableStmt) checkTiDBTableDef(r *ParseResult) {
+	checkTiDBTableDef(r, s.Cols, s.Constraints, s.Options, s.Partition)
 }
\ No newline at end of file
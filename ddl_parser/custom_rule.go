@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/google/cel-go/cel"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// Custom Rule Scope Constant
+const (
+	RuleScopeTable  = "table"
+	RuleScopeColumn = "column"
+	RuleScopeIndex  = "index"
+)
+
+// CustomRuleConfig: organization specific rule declared in `custom_rules` of the parse options
+// `Expr` is a CEL expression evaluated on each object of the scope, the rule is violated when it evaluates to false
+// Variable `table` is available in all scopes, `column` and `index` are available in their own scopes
+// `Message` can refer to the violating object with `{table}`, `{column}` and `{index}`
+// The rule is not applicable to the object without the optional key it refers to, e.g. `column.length` of `BIGINT`
+type CustomRuleConfig struct {
+	ID       string `json:"id"`
+	Scope    string `json:"scope"`    // `table`, `column` or `index`, default to `table`
+	Expr     string `json:"expr"`     // e.g. `size(table.indexes) <= 10`
+	Severity string `json:"severity"` // `error` or `warning`, default to `error`
+	Message  string `json:"message"`
+}
+
+type CustomRule struct {
+	*CustomRuleConfig
+	level   DDLMsgType
+	program cel.Program
+}
+
+// Keys omitted from the rule variables if not applicable to the object, e.g. `length`, `precision` and `scale`
+var customRuleOptionalKeys = getOptionalKeys(Column{}, IndexColumn{}, Partition{}, PartitionDefinition{})
+
+var customRuleEnv, _ = cel.NewEnv(
+	cel.Variable(RuleScopeTable, cel.MapType(cel.StringType, cel.DynType)),
+	cel.Variable(RuleScopeColumn, cel.MapType(cel.StringType, cel.DynType)),
+	cel.Variable(RuleScopeIndex, cel.MapType(cel.StringType, cel.DynType)),
+	cel.CrossTypeNumericComparisons(true),
+)
+
+// NewCustomRules: validate and compile the custom rules
+func NewCustomRules(configs []*CustomRuleConfig) ([]*CustomRule, error) {
+	builtinRules := make(map[string]struct{})
+	for _, rule := range DDLErrorRuleMap {
+		builtinRules[rule] = struct{}{}
+	}
+
+	var rules []*CustomRule
+	ids := make(map[string]struct{})
+	for _, config := range configs {
+		if config.ID == "" {
+			return nil, fmt.Errorf("custom rule must have `id`")
+		}
+		if _, ok := builtinRules[config.ID]; ok {
+			return nil, fmt.Errorf("custom rule `%s` conflicts with built-in rule", config.ID)
+		}
+		if _, ok := ids[config.ID]; ok {
+			return nil, fmt.Errorf("custom rule `%s` is declared more than once", config.ID)
+		}
+		ids[config.ID] = struct{}{}
+
+		rule := &CustomRule{CustomRuleConfig: config}
+		if rule.Scope == "" {
+			rule.Scope = RuleScopeTable
+		}
+		if rule.Scope != RuleScopeTable && rule.Scope != RuleScopeColumn && rule.Scope != RuleScopeIndex {
+			return nil, fmt.Errorf("custom rule `%s` has unknown scope `%s`, expect `%s`, `%s` or `%s`", config.ID,
+				rule.Scope, RuleScopeTable, RuleScopeColumn, RuleScopeIndex)
+		}
+		switch strings.ToLower(rule.Severity) {
+		case "", DDLMsgTypeError.String():
+			rule.level = DDLMsgTypeError
+		case DDLMsgTypeWarning.String():
+			rule.level = DDLMsgTypeWarning
+		default:
+			return nil, fmt.Errorf("custom rule `%s` has unknown severity `%s`, expect `%s` or `%s`", config.ID,
+				rule.Severity, DDLMsgTypeError, DDLMsgTypeWarning)
+		}
+		if rule.Message == "" {
+			rule.Message = fmt.Sprintf("custom rule `%s` is violated", rule.ID)
+		}
+
+		ast, issues := customRuleEnv.Compile(rule.Expr)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("custom rule `%s` has invalid expression: %s", config.ID, issues.Err().Error())
+		}
+		if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+			return nil, fmt.Errorf("custom rule `%s` must evaluate to bool, got %s", config.ID, ast.OutputType())
+		}
+		program, err := customRuleEnv.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("custom rule `%s` has invalid expression: %s", config.ID, err.Error())
+		}
+		rule.program = program
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// checkCustomRules: evaluate the custom rules against the table created or the columns added
+// Index and table scope rules are only evaluated on `CREATE TABLE` where the whole table is known, DML statements are
+// not evaluated
+func checkCustomRules(r *ParseResult, rules []*CustomRule) {
+	if len(rules) == 0 || r.Kind == stmtKindDML {
+		return
+	}
+
+	table := map[string]interface{}{"name": r.OldTable}
+	var columns []*Column
+	var indexes []*Index
+	if r.Table != nil {
+		table = toCustomRuleVar(r.Table)
+		table["comment"] = r.Table.GetOption("comment")
+		indexes = r.Table.Indexes
+	}
+	columns = r.Columns
+
+	for _, rule := range rules {
+		switch rule.Scope {
+		case RuleScopeTable:
+			if r.Table != nil {
+				rule.check(r, map[string]interface{}{RuleScopeTable: table})
+			}
+		case RuleScopeColumn:
+			for _, col := range columns {
+				rule.check(r, map[string]interface{}{RuleScopeTable: table, RuleScopeColumn: toCustomRuleVar(col)})
+			}
+		case RuleScopeIndex:
+			for _, index := range indexes {
+				rule.check(r, map[string]interface{}{RuleScopeTable: table, RuleScopeIndex: toCustomRuleVar(index)})
+			}
+		}
+	}
+}
+
+func (c *CustomRule) check(r *ParseResult, vars map[string]interface{}) {
+	out, _, err := c.program.Eval(vars)
+	if err != nil && isOptionalKeyErr(err) {
+		return
+	} else if err != nil {
+		r.AddError(CustomRuleEvalErr.Accept(c.ID, err.Error()))
+		return
+	}
+	if passed, ok := out.Value().(bool); !ok {
+		r.AddError(CustomRuleEvalErr.Accept(c.ID, fmt.Sprintf("expect bool, got %v", out.Value())))
+	} else if !passed {
+		r.AddRuleError(c.ID, c.level, c.formatMessage(vars))
+	}
+}
+
+// isOptionalKeyErr: the expression refers to the optional key absent from the object
+func isOptionalKeyErr(err error) bool {
+	key := strings.TrimPrefix(err.Error(), "no such key: ")
+	_, ok := customRuleOptionalKeys[key]
+	return ok && key != err.Error()
+}
+
+// getOptionalKeys: JSON field names of the models tagged with `omitempty`
+func getOptionalKeys(models ...interface{}) map[string]struct{} {
+	keys := make(map[string]struct{})
+	for _, model := range models {
+		t := reflect.TypeOf(model)
+		for i := 0; i < t.NumField(); i++ {
+			tag := strings.Split(t.Field(i).Tag.Get("json"), ",")
+			for _, option := range tag[1:] {
+				if option == "omitempty" {
+					keys[tag[0]] = struct{}{}
+				}
+			}
+		}
+	}
+	return keys
+}
+
+// Replace `{table}`, `{column}` and `{index}` in the message with the name of the violating object
+func (c *CustomRule) formatMessage(vars map[string]interface{}) string {
+	var replacements []string
+	for _, scope := range []string{RuleScopeTable, RuleScopeColumn, RuleScopeIndex} {
+		if v, ok := vars[scope].(map[string]interface{}); ok {
+			replacements = append(replacements, "{"+scope+"}", fmt.Sprint(v["name"]))
+		}
+	}
+	return strings.NewReplacer(replacements...).Replace(c.Message)
+}
+
+// toCustomRuleVar: convert the model to CEL map with the JSON field names, whole numbers are converted to int
+func toCustomRuleVar(model interface{}) map[string]interface{} {
+	jsonStr, _ := json.Marshal(model)
+	v := make(map[string]interface{})
+	_ = json.Unmarshal(jsonStr, &v)
+	return normalizeNumbers(v).(map[string]interface{})
+}
+
+func normalizeNumbers(v interface{}) interface{} {
+	switch impl := v.(type) {
+	case float64:
+		if impl == math.Trunc(impl) && math.Abs(impl) < math.MaxInt64 {
+			return int64(impl)
+		}
+	case map[string]interface{}:
+		for key, value := range impl {
+			impl[key] = normalizeNumbers(value)
+		}
+	case []interface{}:
+		for i, value := range impl {
+			impl[i] = normalizeNumbers(value)
+		}
+	}
+	return v
+}
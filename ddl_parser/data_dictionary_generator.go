@@ -0,0 +1,287 @@
+package main
+
+import "C"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"text/template"
+)
+
+// Data Dictionary Format Constant
+const (
+	DictionaryFormatMarkdown = "markdown"
+	DictionaryFormatHTML     = "html"
+)
+
+const markdownDictionaryTemplate = `# {{.Title}}
+{{range .Tables}}
+## {{.Name}}
+{{if .Comment}}
+{{md .Comment}}
+{{end}}
+### Columns
+
+| Name | Type | Nullable | Default | Comment |
+| --- | --- | --- | --- | --- |
+{{- range .Columns}}
+| {{md .Name}} | {{md .Type}} | {{.Nullable}} | {{md .Default}} | {{md .Comment}} |
+{{- end}}
+{{if .Indexes}}
+### Indexes
+
+| Name | Type | Columns | Comment |
+| --- | --- | --- | --- |
+{{- range .Indexes}}
+| {{md .Name}} | {{.Type}} | {{md .Columns}} | {{md .Comment}} |
+{{- end}}
+{{end}}{{if .Partition}}
+### Partition
+
+{{md .Partition}}
+{{if .PartitionDefinitions}}
+| Name | Values | Comment |
+| --- | --- | --- |
+{{- range .PartitionDefinitions}}
+| {{md .Name}} | {{md .Values}} | {{md .Comment}} |
+{{- end}}
+{{end}}{{end}}{{end}}`
+
+const htmlDictionaryTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{- range .Tables}}
+<h2 id="{{.Name}}">{{.Name}}</h2>
+{{- if .Comment}}
+<p>{{.Comment}}</p>
+{{- end}}
+<h3>Columns</h3>
+<table>
+<tr><th>Name</th><th>Type</th><th>Nullable</th><th>Default</th><th>Comment</th></tr>
+{{- range .Columns}}
+<tr><td>{{.Name}}</td><td>{{.Type}}</td><td>{{.Nullable}}</td><td>{{.Default}}</td><td>{{.Comment}}</td></tr>
+{{- end}}
+</table>
+{{- if .Indexes}}
+<h3>Indexes</h3>
+<table>
+<tr><th>Name</th><th>Type</th><th>Columns</th><th>Comment</th></tr>
+{{- range .Indexes}}
+<tr><td>{{.Name}}</td><td>{{.Type}}</td><td>{{.Columns}}</td><td>{{.Comment}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .Partition}}
+<h3>Partition</h3>
+<p>{{.Partition}}</p>
+{{- if .PartitionDefinitions}}
+<table>
+<tr><th>Name</th><th>Values</th><th>Comment</th></tr>
+{{- range .PartitionDefinitions}}
+<tr><td>{{.Name}}</td><td>{{.Values}}</td><td>{{.Comment}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- end}}
+{{- end}}
+</body>
+</html>
+`
+
+// DataDictionaryOptions: options accepted by `GenerateDataDictionary` in JSON format
+type DataDictionaryOptions struct {
+	Format string `json:"format"` // `markdown` or `html`, default to `markdown`
+	Title  string `json:"title"`  // Title of the document, default to `Data Dictionary`
+}
+
+type DataDictionaryResult struct {
+	Format     string   `json:"format"`
+	Dictionary string   `json:"dictionary"`
+	Error      []string `json:"error_msg"`
+}
+
+// DataDictionaryData: data passed to the template
+type DataDictionaryData struct {
+	Title  string
+	Tables []*DataDictionaryTable
+}
+
+type DataDictionaryTable struct {
+	Name                 string
+	Comment              string
+	Columns              []*DataDictionaryColumn
+	Indexes              []*DataDictionaryIndex
+	Partition            string
+	PartitionDefinitions []*PartitionDefinition
+}
+
+type DataDictionaryColumn struct {
+	Name     string
+	Type     string
+	Nullable string
+	Default  string
+	Comment  string
+}
+
+type DataDictionaryIndex struct {
+	Name    string
+	Type    string
+	Columns string
+	Comment string
+}
+
+//export GenerateDataDictionary
+func GenerateDataDictionary(sql string, options string) *C.char {
+	jsonStr, _ := json.Marshal(generateDataDictionary(options, func() (*Catalog, error) {
+		catalog := NewCatalog()
+		return catalog, catalog.ApplySQL(sql)
+	}))
+	return stringToCString(string(jsonStr))
+}
+
+//export GenerateDataDictionaryFromDir
+func GenerateDataDictionaryFromDir(dir string, options string) *C.char {
+	jsonStr, _ := json.Marshal(generateDataDictionary(options, func() (*Catalog, error) {
+		return LoadCatalogFromDir(dir)
+	}))
+	return stringToCString(string(jsonStr))
+}
+
+//export GenerateDataDictionaryFromDB
+func GenerateDataDictionaryFromDB(dsn string, options string) *C.char {
+	jsonStr, _ := json.Marshal(generateDataDictionary(options, func() (*Catalog, error) {
+		return LoadCatalogFromDB(dsn)
+	}))
+	return stringToCString(string(jsonStr))
+}
+
+// generateDataDictionary: generate the data dictionary of the tables in the catalog built by `load`
+func generateDataDictionary(options string, load func() (*Catalog, error)) *DataDictionaryResult {
+	opts := &DataDictionaryOptions{}
+	if strings.TrimSpace(options) != "" {
+		if err := json.Unmarshal([]byte(options), opts); err != nil {
+			return &DataDictionaryResult{Error: []string{InvalidOptionErr.Accept(err.Error()).Error()}}
+		}
+	}
+	if opts.Format == "" {
+		opts.Format = DictionaryFormatMarkdown
+	}
+	if opts.Title == "" {
+		opts.Title = "Data Dictionary"
+	}
+	result := &DataDictionaryResult{Format: opts.Format}
+	if opts.Format != DictionaryFormatMarkdown && opts.Format != DictionaryFormatHTML {
+		result.Error = append(result.Error, InvalidOptionErr.Accept(fmt.Sprintf("unknown dictionary format `%s`, "+
+			"expect `%s` or `%s`", opts.Format, DictionaryFormatMarkdown, DictionaryFormatHTML)).Error())
+		return result
+	}
+
+	catalog, err := load()
+	if err != nil {
+		result.Error = append(result.Error, err.Error())
+		return result
+	}
+
+	data := buildDataDictionaryData(catalog, opts.Title)
+	var buf bytes.Buffer
+	if opts.Format == DictionaryFormatHTML {
+		err = htmltemplate.Must(htmltemplate.New("data_dictionary").Parse(htmlDictionaryTemplate)).Execute(&buf, data)
+	} else {
+		err = template.Must(template.New("data_dictionary").Funcs(template.FuncMap{"md": escapeMarkdown}).
+			Parse(markdownDictionaryTemplate)).Execute(&buf, data)
+	}
+	if err != nil {
+		result.Error = append(result.Error, err.Error())
+		return result
+	}
+	result.Dictionary = buf.String()
+	return result
+}
+
+func buildDataDictionaryData(catalog *Catalog, title string) *DataDictionaryData {
+	data := &DataDictionaryData{Title: title}
+	for _, table := range catalog.Tables {
+		dictTable := &DataDictionaryTable{
+			Name:    table.Name,
+			Comment: table.GetOption("comment"),
+		}
+
+		for _, col := range table.Columns {
+			dictCol := &DataDictionaryColumn{
+				Name:     col.Name,
+				Type:     col.ColumnType,
+				Nullable: "NO",
+				Comment:  col.Comment,
+			}
+			if col.Nullable {
+				dictCol.Nullable = "YES"
+			}
+			if col.Default != nil {
+				dictCol.Default = *col.Default
+			} else if col.Nullable && col.Generated == nil {
+				dictCol.Default = "NULL"
+			}
+			if col.OnUpdate != nil {
+				dictCol.Default = strings.TrimSpace(dictCol.Default + " ON UPDATE " + *col.OnUpdate)
+			}
+			if col.AutoIncrement {
+				dictCol.Default = "AUTO_INCREMENT"
+			}
+			if col.Generated != nil {
+				dictCol.Default = "AS (" + *col.Generated + ")"
+			}
+			dictTable.Columns = append(dictTable.Columns, dictCol)
+		}
+
+		for _, index := range table.Indexes {
+			dictTable.Indexes = append(dictTable.Indexes, &DataDictionaryIndex{
+				Name:    index.Name,
+				Type:    index.Type,
+				Columns: getIndexColumnNames(index),
+				Comment: index.Comment,
+			})
+		}
+
+		if part := table.Partition; part != nil {
+			dictTable.Partition = getPartitionMethodString(part)
+			if part.Sub != nil {
+				dictTable.Partition += " SUBPARTITION BY " + getPartitionMethodString(part.Sub)
+			}
+			dictTable.PartitionDefinitions = part.Definitions
+		}
+		data.Tables = append(data.Tables, dictTable)
+	}
+	return data
+}
+
+// Return the partition method, e.g. `RANGE COLUMNS(created_at)`, `KEY(id)` or `LINEAR HASH(id) PARTITIONS 4`
+// `COLUMNS` is only used by `RANGE` and `LIST`, the columns of `KEY` are given without it
+func getPartitionMethodString(part *Partition) string {
+	method := part.Type
+	if part.Linear {
+		method = "LINEAR " + method
+	}
+	if part.Expr != "" {
+		method += "(" + part.Expr + ")"
+	} else if part.Type == "RANGE" || part.Type == "LIST" {
+		method += " COLUMNS(" + strings.Join(part.Columns, ",") + ")"
+	} else {
+		method += "(" + strings.Join(part.Columns, ",") + ")"
+	}
+	if part.Num > 0 && len(part.Definitions) == 0 {
+		method += fmt.Sprintf(" PARTITIONS %d", part.Num)
+	}
+	return method
+}
+
+// Escape the text to be placed in markdown table cell
+func escapeMarkdown(text string) string {
+	return strings.NewReplacer("|", "\\|", "\r\n", "<br>", "\n", "<br>").Replace(text)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Cases of the data dictionary, the options are given in the leading comment of the case, e.g.
+// `-- options: {"format": "html"}`, and the dictionary is compared with the `.md` or `.html` golden file
+const dataDictionaryGoldenDir = "testdata/data_dictionary"
+
+func TestDataDictionaryGolden(t *testing.T) {
+	for _, sqlFile := range getGoldenFiles(t, dataDictionaryGoldenDir) {
+		sqlFile := sqlFile
+		t.Run(strings.TrimSuffix(filepath.Base(sqlFile), ".sql"), func(t *testing.T) {
+			content, err := ioutil.ReadFile(sqlFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sql := string(content)
+			result := generateDataDictionary(getGoldenOptions(sql), func() (*Catalog, error) {
+				catalog := NewCatalog()
+				return catalog, catalog.ApplySQL(sql)
+			})
+			if len(result.Error) != 0 {
+				t.Fatalf("unexpected error %v", result.Error)
+			}
+
+			ext := ".md"
+			if result.Format == DictionaryFormatHTML {
+				ext = ".html"
+			}
+			checkGoldenFile(t, strings.TrimSuffix(sqlFile, ".sql")+ext, []byte(result.Dictionary))
+		})
+	}
+}
+
+// TestDataDictionaryError: the errors of the options and the replay are reported without dictionary
+func TestDataDictionaryError(t *testing.T) {
+	for _, c := range []struct {
+		sql     string
+		options string
+		err     string
+	}{
+		{"CREATE TABLE example (id BIGINT);", `{"format": "pdf"}`, "unknown dictionary format `pdf`"},
+		{"CREATE TABLE example (id BIGINT);", `{"format": `, "invalid parse options"},
+		{"DROP TABLE example;", "", "table `example` does not exist"},
+	} {
+		result := generateDataDictionary(c.options, func() (*Catalog, error) {
+			catalog := NewCatalog()
+			return catalog, catalog.ApplySQL(c.sql)
+		})
+		if len(result.Error) != 1 || !strings.Contains(result.Error[0], c.err) || result.Dictionary != "" {
+			t.Errorf("expect error %q for %s with options %s, got %+v", c.err, c.sql, c.options, result)
+		}
+	}
+}
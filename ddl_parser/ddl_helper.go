@@ -54,8 +54,9 @@ const (
 // DDL Error
 var (
 	// General DDL Error
-	SyntaxErr    = NewCustomError("syntax error at %s")
-	NoneDDLErr   = errors.New("statement provided is not a valid DDL")
+	SyntaxErr        = NewCustomError("syntax error at %s")
+	NoneDDLErr       = errors.New("statement provided is not a valid DDL")
+	InvalidOptionErr = NewCustomError("invalid parse options: %s")
 
 	// Unsupported DDL Type Error
 	RenameTableErr       = errors.New("please use ALTER TABLE for rename operation")
@@ -136,6 +137,50 @@ var (
 	PartWithHashErr       = NewCustomError("use of `BY HASH` is not recommended in partition <%s>")
 	PartDroppedErr        = errors.New("drop partition required DBOps's confirmation")
 	PartRemovedErr        = errors.New("remove partition required DBOps's confirmation")
+
+	// Dialect Error
+	UnsupportedFeatureErr = NewCustomError("use of %s is not supported by %s in <%s>")
+	CheckNotEnforcedErr   = NewCustomError("`CHECK` constraint is parsed but not enforced by %s in <%s>")
+
+	// TiDB Error
+	TiDBOptionIgnoredErr         = NewCustomError("TiDB specific %s is ignored by %s")
+	TiDBAutoIncHotspotErr        = NewCustomError("`AUTO_INCREMENT` on clustered `BIGINT` primary key `%s` creates write hotspot in TiDB, use `AUTO_RANDOM` instead")
+	TiDBAutoRandomNotPKErr       = NewCustomError("`AUTO_RANDOM` column `%s` must be the clustered `BIGINT` primary key")
+	TiDBAutoRandomConflictErr    = NewCustomError("`AUTO_RANDOM` column `%s` cannot use `%s`")
+	TiDBAutoRandomAddedErr       = NewCustomError("add `AUTO_RANDOM` column `%s` is not supported by TiDB")
+	TiDBRowIDHotspotErr          = errors.New("table without clustered primary key should set `SHARD_ROW_ID_BITS` to scatter the implicit row id in TiDB")
+	TiDBShardRowIDOnClusteredErr = errors.New("`SHARD_ROW_ID_BITS` cannot be used on table with clustered primary key in TiDB")
+	TiDBShardRowIDTooLargeErr    = fmt.Errorf("`SHARD_ROW_ID_BITS` must be no more than %d", tidbMaxShardRowIDBits)
+	TiDBPreSplitWithoutShardErr  = errors.New("`PRE_SPLIT_REGIONS` requires `SHARD_ROW_ID_BITS` or `AUTO_RANDOM`")
+	TiDBPreSplitTooLargeErr      = errors.New("`PRE_SPLIT_REGIONS` must not exceed `SHARD_ROW_ID_BITS`")
+
+	// Custom Rule Error
+	CustomRuleEvalErr = NewCustomError("custom rule `%s` failed to evaluate: %s")
+
+	// Baseline Error
+	BaselineSaveErr = NewCustomError("failed to save baseline `%s`: %s")
+
+	// Schema Error
+	SchemaMismatchErr = NewCustomError("statement does not apply to the schema: %s")
+
+	// Dry Run Error
+	DryRunErr            = NewCustomError("statement failed in dry run: %s")
+	DryRunUnsupportedErr = NewCustomError("statement is not supported by dry run: %s")
+	DryRunSkippedErr     = NewCustomError("dry run is skipped: %s")
+
+	// Table Size Error
+	LargeTableRebuildErr = NewCustomError("statement rebuilds large table `%s` with %d rows and %s, estimated to take %s")
+	LargeTableIndexErr   = NewCustomError("statement builds index on large table `%s` with %d rows and %s, estimated to take %s")
+
+	// Waiver Error
+	WaiverExpiredErr   = NewCustomError("waiver of `%s` on table `%s` in ticket %s expired on %s")
+	WaiverUnmatchedErr = NewCustomError("waiver of `%s` on table `%s` in ticket %s does not match any finding")
+)
+
+// Approval Role Constant
+const (
+	ApprovalRoleDE    = "DE"
+	ApprovalRoleDBOps = "DBOps"
 )
 
 type DDLMsgType int
@@ -146,11 +191,23 @@ const (
 	DDLMsgTypeIgnore
 )
 
+func (t DDLMsgType) String() string {
+	switch t {
+	case DDLMsgTypeError:
+		return "error"
+	case DDLMsgTypeWarning:
+		return "warning"
+	default:
+		return "ignore"
+	}
+}
+
 var (
 	// Mapping from error to DDLMsgType to determine what critical level the error should be reported
 	DDLErrorMsgTypeMap = map[error]DDLMsgType{
 		SyntaxErr:                      DDLMsgTypeError,
 		NoneDDLErr:                     DDLMsgTypeError,
+		InvalidOptionErr:               DDLMsgTypeError,
 		RenameTableErr:                 DDLMsgTypeError,
 		ModifyIndexErr:                 DDLMsgTypeError,
 		ModifyDatabaseErr:              DDLMsgTypeError,
@@ -216,11 +273,134 @@ var (
 		PartWithHashErr:                DDLMsgTypeWarning,
 		PartDroppedErr:                 DDLMsgTypeWarning,
 		PartRemovedErr:                 DDLMsgTypeWarning,
+		UnsupportedFeatureErr:          DDLMsgTypeError,
+		CheckNotEnforcedErr:            DDLMsgTypeWarning,
+		TiDBOptionIgnoredErr:           DDLMsgTypeWarning,
+		TiDBAutoIncHotspotErr:          DDLMsgTypeWarning,
+		TiDBAutoRandomNotPKErr:         DDLMsgTypeError,
+		TiDBAutoRandomConflictErr:      DDLMsgTypeError,
+		TiDBAutoRandomAddedErr:         DDLMsgTypeError,
+		TiDBRowIDHotspotErr:            DDLMsgTypeWarning,
+		TiDBShardRowIDOnClusteredErr:   DDLMsgTypeError,
+		TiDBShardRowIDTooLargeErr:      DDLMsgTypeError,
+		TiDBPreSplitWithoutShardErr:    DDLMsgTypeError,
+		TiDBPreSplitTooLargeErr:        DDLMsgTypeError,
+		CustomRuleEvalErr:              DDLMsgTypeError,
+		BaselineSaveErr:                DDLMsgTypeError,
+		SchemaMismatchErr:              DDLMsgTypeError,
+		DryRunErr:                      DDLMsgTypeError,
+		DryRunUnsupportedErr:           DDLMsgTypeWarning,
+		DryRunSkippedErr:               DDLMsgTypeWarning,
+		LargeTableRebuildErr:           DDLMsgTypeError,
+		LargeTableIndexErr:             DDLMsgTypeWarning,
+		WaiverExpiredErr:               DDLMsgTypeError,
+		WaiverUnmatchedErr:             DDLMsgTypeWarning,
+	}
+
+	// Mapping from error to rule ID to identify which rule the finding is reported by
+	DDLErrorRuleMap = map[error]string{
+		SyntaxErr:                      "SyntaxErr",
+		NoneDDLErr:                     "NoneDDLErr",
+		InvalidOptionErr:               "InvalidOptionErr",
+		RenameTableErr:                 "RenameTableErr",
+		ModifyIndexErr:                 "ModifyIndexErr",
+		ModifyDatabaseErr:              "ModifyDatabaseErr",
+		DeleteTableErr:                 "DeleteTableErr",
+		UnsupportedClauseErr:           "UnsupportedClauseErr",
+		TempTableErr:                   "TempTableErr",
+		UseIfNotExistsErr:              "UseIfNotExistsErr",
+		CreateWithSelectErr:            "CreateWithSelectErr",
+		CreateWithLikeErr:              "CreateWithLikeErr",
+		TableWithDBNameErr:             "TableWithDBNameErr",
+		TableReservedWordErr:           "TableReservedWordErr",
+		TableNotLowerCaseErr:           "TableNotLowerCaseErr",
+		TableNameWithHyphenErr:         "TableNameWithHyphenErr",
+		ColIDNotFoundErr:               "ColIDNotFoundErr",
+		ColIDNotAutoIncErr:             "ColIDNotAutoIncErr",
+		ColIDNotBigIntErr:              "ColIDNotBigIntErr",
+		ColIDNotUnsignedErr:            "ColIDNotUnsignedErr",
+		ColIDDroppedErr:                "ColIDDroppedErr",
+		ColCreatedAtNotFoundErr:        "ColCreatedAtNotFoundErr",
+		ColCreatedAtNotDateTimeErr:     "ColCreatedAtNotDateTimeErr",
+		ColCreatedAtNotNotNullErr:      "ColCreatedAtNotNotNullErr",
+		ColCreatedAtInvalidDefValErr:   "ColCreatedAtInvalidDefValErr",
+		ColCreatedAtDroppedErr:         "ColCreatedAtDroppedErr",
+		ColUpdatedAtNotFoundErr:        "ColUpdatedAtNotFoundErr",
+		ColUpdatedAtNotNotNullErr:      "ColUpdatedAtNotNotNullErr",
+		ColUpdatedAtNotDateTimeErr:     "ColUpdatedAtNotDateTimeErr",
+		ColUpdatedAtInvalidDefValErr:   "ColUpdatedAtInvalidDefValErr",
+		ColUpdatedAtInvalidOnUpdateErr: "ColUpdatedAtInvalidOnUpdateErr",
+		ColUpdatedAtDroppedErr:         "ColUpdatedAtDroppedErr",
+		ColDroppedErr:                  "ColDroppedErr",
+		ColNameWithHyphenErr:           "ColNameWithHyphenErr",
+		ColInlineKeyErr:                "ColInlineKeyErr",
+		ColTimeStampTypeErr:            "ColTimeStampTypeErr",
+		ColNotNullDefaultErr:           "ColNotNullDefaultErr",
+		ColDisplayLengthIntErr:         "ColDisplayLengthIntErr",
+		ColEnumTypeErr:                 "ColEnumTypeErr",
+		ColReservedWordErr:             "ColReservedWordErr",
+		ColNotNullDefaultNullErr:       "ColNotNullDefaultNullErr",
+		ColNameNotLowerCaseErr:         "ColNameNotLowerCaseErr",
+		ColNotNullWithoutDefaultErr:    "ColNotNullWithoutDefaultErr",
+		ColReorderWithFirstErr:         "ColReorderWithFirstErr",
+		ColReorderWithAfterErr:         "ColReorderWithAfterErr",
+		PrimaryKeyNotFoundErr:          "PrimaryKeyNotFoundErr",
+		PrimaryKeyDroppedErr:           "PrimaryKeyDroppedErr",
+		PrimaryKeyIDNotFoundErr:        "PrimaryKeyIDNotFoundErr",
+		IndexNamePrefixErr:             "IndexNamePrefixErr",
+		UniqueKeyPrefixErr:             "UniqueKeyPrefixErr",
+		UniqueKeyPartKeyNotFoundErr:    "UniqueKeyPartKeyNotFoundErr",
+		CompKeyNoEndRangeKeyErr:        "CompKeyNoEndRangeKeyErr",
+		KeyCreatedAtNotFoundErr:        "KeyCreatedAtNotFoundErr",
+		KeyCreatedAtFormatErr:          "KeyCreatedAtFormatErr",
+		KeyCreatedAtDroppedErr:         "KeyCreatedAtDroppedErr",
+		KeyUpdatedAtNotFoundErr:        "KeyUpdatedAtNotFoundErr",
+		KeyUpdatedAtFormatErr:          "KeyUpdatedAtFormatErr",
+		KeyUpdatedAtDroppedErr:         "KeyUpdatedAtDroppedErr",
+		ConWithUnknownColErr:           "ConWithUnknownColErr",
+		ForeignKeyErr:                  "ForeignKeyErr",
+		BadCollateErr:                  "BadCollateErr",
+		NoCharsetErr:                   "NoCharsetErr",
+		NoCollateErr:                   "NoCollateErr",
+		InvalidEngineErr:               "InvalidEngineErr",
+		PartWithUnknownColErr:          "PartWithUnknownColErr",
+		PartWithHashErr:                "PartWithHashErr",
+		PartDroppedErr:                 "PartDroppedErr",
+		PartRemovedErr:                 "PartRemovedErr",
+		UnsupportedFeatureErr:          "UnsupportedFeatureErr",
+		CheckNotEnforcedErr:            "CheckNotEnforcedErr",
+		TiDBOptionIgnoredErr:           "TiDBOptionIgnoredErr",
+		TiDBAutoIncHotspotErr:          "TiDBAutoIncHotspotErr",
+		TiDBAutoRandomNotPKErr:         "TiDBAutoRandomNotPKErr",
+		TiDBAutoRandomConflictErr:      "TiDBAutoRandomConflictErr",
+		TiDBAutoRandomAddedErr:         "TiDBAutoRandomAddedErr",
+		TiDBRowIDHotspotErr:            "TiDBRowIDHotspotErr",
+		TiDBShardRowIDOnClusteredErr:   "TiDBShardRowIDOnClusteredErr",
+		TiDBShardRowIDTooLargeErr:      "TiDBShardRowIDTooLargeErr",
+		TiDBPreSplitWithoutShardErr:    "TiDBPreSplitWithoutShardErr",
+		TiDBPreSplitTooLargeErr:        "TiDBPreSplitTooLargeErr",
+		CustomRuleEvalErr:              "CustomRuleEvalErr",
+		BaselineSaveErr:                "BaselineSaveErr",
+		SchemaMismatchErr:              "SchemaMismatchErr",
+		DryRunErr:                      "DryRunErr",
+		DryRunUnsupportedErr:           "DryRunUnsupportedErr",
+		DryRunSkippedErr:               "DryRunSkippedErr",
+		LargeTableRebuildErr:           "LargeTableRebuildErr",
+		LargeTableIndexErr:             "LargeTableIndexErr",
+		WaiverExpiredErr:               "WaiverExpiredErr",
+		WaiverUnmatchedErr:             "WaiverUnmatchedErr",
+	}
+
+	// Mapping from error to the role whose approval is required, can be overridden by `approval_roles` of the options
+	DDLErrorApprovalMap = map[error]string{
+		ColDroppedErr:  ApprovalRoleDE,
+		PartDroppedErr: ApprovalRoleDBOps,
+		PartRemovedErr: ApprovalRoleDBOps,
 	}
 )
 
 var (
-	// All reserved words in MySQL 5.7
+	// All reserved words in MySQL 5.7, reserved words of other targets are derived from it in `getReservedWords`
 	reservedWords = map[string]struct{}{
 		"ACCESSIBLE":                    {},
 		"ADD":                           {},
@@ -483,6 +663,9 @@ func (c *CustomError) Error() string {
 type ReturnError struct {
 	errorMsg string
 	level    DDLMsgType
+	rule     string
+	approval string // Role whose approval is required
+	waiver   *Waiver
 }
 
 func (r *ReturnError) Error() string {
@@ -491,4 +674,16 @@ func (r *ReturnError) Error() string {
 
 func (r *ReturnError) Level() DDLMsgType {
 	return r.level
-}
\ No newline at end of file
+}
+
+func (r *ReturnError) Rule() string {
+	return r.rule
+}
+
+func (r *ReturnError) Approval() string {
+	return r.approval
+}
+
+func (r *ReturnError) Waiver() *Waiver {
+	return r.waiver
+}
@@ -3,18 +3,111 @@ package main
 import "C"
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/pingcap/parser"
 	"github.com/pingcap/parser/ast"
 	"github.com/pingcap/parser/format"
 	_ "github.com/pingcap/tidb/types/parser_driver"
+	"github.com/sidai/parser/dml"
+	"github.com/sidai/parser/fingerprint"
+	"sort"
 	"strings"
 )
 
 func main() {}
 
+// Kind of the statement in the script
+const (
+	stmtKindDDL   = "ddl"
+	stmtKindDML   = "dml"
+	stmtKindOther = "other"
+)
+
 //export Parse
 func Parse(sql string) *C.char {
-	return toCGOReturn(parse(sql))
+	results := parse(sql, DefaultTarget(), nil, nil)
+	assessRisks(results, nil, nil)
+	return toCGOReturn(results)
+}
+
+//export ParseWithOptions
+func ParseWithOptions(sql string, options string) *C.char {
+	return toCGOReturn(parseWithOptions(sql, options))
+}
+
+//export ParseScript
+func ParseScript(sql string, options string) *C.char {
+	return toCGOScriptReturn(parseWithOptions(sql, options))
+}
+
+// ParseMixedScript: DDL statements are checked and DML statements are analyzed in the same script, e.g. migration
+// seeding the data of the table created, the options of the DML analyzer are given in `dml`
+//export ParseMixedScript
+func ParseMixedScript(sql string, options string) *C.char {
+	return toCGOScriptReturn(parseMixedScript(sql, options))
+}
+
+func parseWithOptions(sql string, options string) []*ParseResult {
+	return NewParseOptions(options).parse(sql)
+}
+
+// parseMixedScript: the DML statements are routed to the DML analyzer even if `dml` is not given
+func parseMixedScript(sql string, options string) []*ParseResult {
+	opts := NewParseOptions(options)
+	if opts.DML == nil {
+		opts.DML = &dml.ParseOptions{}
+	}
+	return opts.parse(sql)
+}
+
+func (o *ParseOptions) parse(sql string) []*ParseResult {
+	target, err := o.Target()
+	var rules []*CustomRule
+	if err == nil {
+		rules, err = NewCustomRules(o.CustomRules)
+	}
+	if err == nil {
+		err = validateApprovalRoles(o.ApprovalRoles, rules)
+	}
+	if err == nil && o.DML != nil {
+		err = o.DML.Normalize()
+	}
+	var catalog *Catalog
+	if err == nil && o.SchemaDir != "" && o.SchemaDSN != "" {
+		err = fmt.Errorf("`schema_dir` and `schema_dsn` cannot be used at the same time")
+	} else if err == nil && o.SchemaDir != "" {
+		catalog, err = LoadCatalogFromDir(o.SchemaDir)
+	} else if err == nil && o.SchemaDSN != "" {
+		catalog, err = LoadCatalogFromDB(o.SchemaDSN)
+	}
+	var sizes *TableSizes
+	if err == nil && o.TableSizes != "" {
+		sizes, err = LoadTableSizes(o.TableSizes, o.TableSizeConfig)
+	}
+	if err != nil {
+		result := NewParseResult(sql, DefaultTarget())
+		result.AddError(InvalidOptionErr.Accept(err.Error()))
+		return []*ParseResult{result}
+	}
+
+	results := parse(sql, target, o.DML, catalog)
+	for _, r := range results {
+		checkCustomRules(r, rules)
+	}
+	if o.DryRun {
+		dryRun(results, catalog)
+	}
+	assessRisks(results, catalog, sizes)
+	checkTableSizes(results, sizes)
+	// After all the findings are added, so that the roles of the findings on the schema and table size apply
+	applyApprovalRoles(results, o.ApprovalRoles)
+	if o.Waivers != "" {
+		applyWaivers(results, o.Waivers)
+	}
+	if o.Baseline != nil {
+		applyBaseline(results, o.Baseline)
+	}
+	return results
 }
 
 func toCGOReturn(parseResults []*ParseResult) (*C.char) {
@@ -27,19 +120,121 @@ func toCGOReturn(parseResults []*ParseResult) (*C.char) {
 	return stringToCString(string(jsonStr))
 }
 
+func toCGOScriptReturn(parseResults []*ParseResult) *C.char {
+	script := &ScriptResult{Risk: getBatchRisk(parseResults)}
+	for _, r := range parseResults {
+		script.Results = append(script.Results, r.toReturnResult())
+	}
+
+	jsonStr, _ := json.Marshal(script)
+	return stringToCString(string(jsonStr))
+}
+
 func stringToCString(str string) *C.char {
 	cs := C.CString(str)
 	return cs
 }
 
 type ReturnResult struct {
-	SQL      string            `json:"sql"`
-	OldTable string            `json:"old_table"`
-	NewTable string            `json:"new_table"`
-	Columns  map[string]string `json:"columns"`
-	DDLType  []string          `json:"ddl_list"`
-	Error    []string          `json:"error_msg"`
-	Warning  []string          `json:"warning_msg"`
+	SQL       string           `json:"sql"`
+	Position  Position         `json:"position"`
+	Kind      string           `json:"kind"` // One of `ddl`, `dml` or `other`
+	OldTable  string           `json:"old_table"`
+	NewTable  string           `json:"new_table"`
+	Columns   []*Column        `json:"columns"`
+	Table     *Table           `json:"table,omitempty"`
+	DDLType   []string         `json:"ddl_list"`
+	Error     []string         `json:"error_msg"`
+	Warning   []string         `json:"warning_msg"`
+	Findings  []*ReturnFinding `json:"findings"`
+	Baselined []*ReturnFinding `json:"baselined,omitempty"` // Findings accepted by the baseline
+
+	// Approvals required by the findings of the statement
+	RequiresApproval []*Approval `json:"requires_approval"`
+	Risk             *Risk       `json:"risk"`
+
+	Estimate *DurationEstimate `json:"estimate,omitempty"` // Only given if the table size is known
+
+	// Normalized statement with literals replaced, shared by the statements differ only in formatting or literals
+	Fingerprint *fingerprint.Fingerprint `json:"fingerprint"`
+
+	// Analysis of the DML statement, only given if the DML statements are routed to the DML analyzer, its findings
+	// are reported in `findings` as well
+	DML *dml.ParseResult `json:"dml,omitempty"`
+}
+
+// ScriptResult: results of the statements in the script and the risk of the whole script
+type ScriptResult struct {
+	Results []*ReturnResult `json:"results"`
+	Risk    *Risk           `json:"risk"`
+}
+
+type ReturnFinding struct {
+	Rule     string   `json:"rule"`
+	Level    string   `json:"level"`
+	Message  string   `json:"message"`
+	Position Position `json:"position"`
+	Waiver   *Waiver  `json:"waiver,omitempty"`
+
+	RequiresApproval *Approval `json:"requires_approval,omitempty"`
+}
+
+// Position: start of the statement in the given sql, `Line` and `Column` start from 1
+type Position struct {
+	Offset int `json:"offset"`
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+
+// ParseOptions: options accepted by `ParseWithOptions` in JSON format
+type ParseOptions struct {
+	Dialect string `json:"dialect"` // One of `mysql`, `mariadb` or `tidb`, default to `mysql`
+	Version string `json:"version"` // Server version of the dialect, e.g. `5.7`, `8.0.16`, `10.5` or `4.0`
+	TiDB    struct {
+		AlterPrimaryKey bool `json:"alter_primary_key"` // TiDB config `alter-primary-key`
+	} `json:"tidb"`
+	CustomRules []*CustomRuleConfig `json:"custom_rules"` // Organization specific rules in CEL, see `CustomRuleConfig`
+	Waivers     string              `json:"waivers"`      // Waivers file granting exceptions of the rules, see `Waivers`
+	Baseline    *BaselineOptions    `json:"baseline"`     // Report only the findings not in the baseline file
+
+	// Role whose approval is required by the rule, overriding `DDLErrorApprovalMap`, e.g. `{"ColDroppedErr": "DBA"}`
+	ApprovalRoles map[string]string `json:"approval_roles"`
+
+	// Schema before the sql, the statements are validated against it and the old column type is compared in risk
+	SchemaDir  string `json:"schema_dir"` // Migration directory replayed as the schema
+	SchemaDSN  string `json:"schema_dsn"` // Database deployed, see `LoadCatalogFromDB`
+	TableSizes string `json:"table_sizes"` // Snapshot of `information_schema.TABLES` in JSON or CSV, see `TableSizes`
+
+	TableSizeConfig *TableSizeConfig `json:"table_size_config"`
+
+	// Run the statements in embedded TiDB on the schema to catch the errors only raised in execution, see `DryRunner`
+	DryRun bool `json:"dry_run"`
+
+	// Analyze `DELETE`, `UPDATE` and `INSERT` by the DML analyzer instead of rejecting them if given, see `dml.ParseOptions`
+	DML *dml.ParseOptions `json:"dml"`
+
+	err error
+}
+
+func NewParseOptions(options string) *ParseOptions {
+	opts := &ParseOptions{}
+	if strings.TrimSpace(options) != "" {
+		opts.err = json.Unmarshal([]byte(options), opts)
+	}
+	return opts
+}
+
+func (o *ParseOptions) Target() (*Target, error) {
+	if o.err != nil {
+		return nil, o.err
+	}
+	target, err := NewTarget(o.Dialect, o.Version)
+	if err != nil {
+		return nil, err
+	}
+	target.AlterPrimaryKey = o.TiDB.AlterPrimaryKey
+	return target, nil
 }
 
 type Parser interface {
@@ -47,40 +242,95 @@ type Parser interface {
 }
 
 type ParseResult struct {
-	SQL      string
-	OldTable string
-	NewTable string
-	Columns  map[string]string
-	DDLType  map[string]struct{}
-	Error    []*ReturnError
+	SQL         string
+	Position    Position
+	Kind        string
+	OldTable    string
+	NewTable    string
+	Columns     []*Column
+	Table       *Table
+	DDLType     []string
+	Error       []*ReturnError
+	Baselined   []*ReturnError
+	Risk        *Risk
+	Estimate    *DurationEstimate
+	Fingerprint *fingerprint.Fingerprint
+	DML         *dml.ParseResult
+	Stmt        ast.StmtNode
+	Target      *Target
 }
 
+// toReturnResult: convert to the output format, findings are sorted by position, then rule, then message for
+// deterministic output, and the error and warning messages and the approvals follow the order of the findings
 func (r *ParseResult) toReturnResult() *ReturnResult {
 	returnResult := &ReturnResult{
 		SQL:      r.SQL,
+		Position: r.Position,
+		Kind:     r.Kind,
 		OldTable: r.OldTable,
 		NewTable: r.NewTable,
 		Columns:  r.Columns,
-	}
+		Table:    r.Table,
+		DDLType:  r.DDLType,
+		Risk:     r.Risk,
+		Estimate: r.Estimate,
 
-	for ddlType, _ := range r.DDLType {
-		returnResult.DDLType = append(returnResult.DDLType, ddlType)
+		Fingerprint: r.Fingerprint,
+		DML:         r.DML,
 	}
+
 	for _, err := range r.Error {
-		if err.Level() == DDLMsgTypeError {
-			returnResult.Error = append(returnResult.Error, err.Error())
-		} else if err.Level() == DDLMsgTypeWarning {
-			returnResult.Warning = append(returnResult.Warning, err.Error())
+		if err.Level() == DDLMsgTypeError || err.Level() == DDLMsgTypeWarning {
+			returnResult.Findings = append(returnResult.Findings, toReturnFinding(err, r.Position))
 		}
 	}
+	sortReturnFindings(returnResult.Findings)
+	for _, finding := range returnResult.Findings {
+		if finding.Level == DDLMsgTypeError.String() {
+			returnResult.Error = append(returnResult.Error, finding.Message)
+		} else {
+			returnResult.Warning = append(returnResult.Warning, finding.Message)
+		}
+		if finding.RequiresApproval != nil {
+			returnResult.RequiresApproval = append(returnResult.RequiresApproval, finding.RequiresApproval)
+		}
+	}
+	for _, err := range r.Baselined {
+		returnResult.Baselined = append(returnResult.Baselined, toReturnFinding(err, r.Position))
+	}
+	sortReturnFindings(returnResult.Baselined)
 	return returnResult
 }
 
-func NewParseResult(sql string) *ParseResult {
+func toReturnFinding(err *ReturnError, pos Position) *ReturnFinding {
+	return &ReturnFinding{
+		Rule:     err.Rule(),
+		Level:    err.Level().String(),
+		Message:  err.Error(),
+		Position: pos,
+		Waiver:   err.Waiver(),
+
+		RequiresApproval: getApproval(err),
+	}
+}
+
+func sortReturnFindings(findings []*ReturnFinding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Position.Offset != findings[j].Position.Offset {
+			return findings[i].Position.Offset < findings[j].Position.Offset
+		}
+		if findings[i].Rule != findings[j].Rule {
+			return findings[i].Rule < findings[j].Rule
+		}
+		return findings[i].Message < findings[j].Message
+	})
+}
+
+func NewParseResult(sql string, target *Target) *ParseResult {
 	return &ParseResult{
 		SQL:      sql,
-		Columns:  make(map[string]string),
-		DDLType:  make(map[string]struct{}),
+		Position: Position{Line: 1, Column: 1},
+		Target:   target,
 	}
 }
 
@@ -92,44 +342,107 @@ func (r *ParseResult) SetNewTable(tableName string) {
 	r.NewTable = tableName
 }
 
-func (r *ParseResult) AddColumn(colName string, colType string) {
-	r.Columns[colName] = colType
+func (r *ParseResult) SetPosition(pos Position) {
+	r.Position = pos
+}
+
+func (r *ParseResult) SetTable(table *Table) {
+	r.Table = table
 }
 
-func (r *ParseResult) AddColumns(cols map[string]string) {
-	for colName, colType := range cols {
-		r.AddColumn(colName, colType)
+// AddColumn: add the column in declaration order, the type is overwritten if the column is added before
+func (r *ParseResult) AddColumn(col *Column) {
+	for i, added := range r.Columns {
+		if added.Name == col.Name {
+			r.Columns[i] = col
+			return
+		}
+	}
+	r.Columns = append(r.Columns, col)
+}
+
+func (r *ParseResult) AddColumns(cols []*Column) {
+	for _, col := range cols {
+		r.AddColumn(col)
 	}
 }
 
+// AddDDLType: add the DDL type in the order encountered, duplicated type is ignored
 func (r *ParseResult) AddDDLType(ddlType string) {
-	r.DDLType[ddlType] = struct{}{}
+	for _, added := range r.DDLType {
+		if added == ddlType {
+			return
+		}
+	}
+	r.DDLType = append(r.DDLType, ddlType)
 }
 
 func (r *ParseResult) AddError(err error) {
 	r.Error = append(r.Error, &ReturnError{
 		errorMsg: err.Error(),
 		level: DDLErrorMsgTypeMap[err],
+		rule:  DDLErrorRuleMap[err],
+		approval: DDLErrorApprovalMap[err],
+	})
+}
+
+// AddDMLResult: the findings of the DML analyzer are added as the findings of the rules not in `DDLErrorRuleMap`
+func (r *ParseResult) AddDMLResult(result *dml.ParseResult) {
+	r.DML = result
+	for _, finding := range result.Findings {
+		level := DDLMsgTypeWarning
+		if finding.Level == dml.DMLMsgTypeError.String() {
+			level = DDLMsgTypeError
+		}
+		r.AddRuleError(finding.Rule, level, finding.Message)
+	}
+}
+
+// AddRuleError: add the finding reported by rule not defined in `DDLErrorRuleMap`, e.g. custom rule
+func (r *ParseResult) AddRuleError(rule string, level DDLMsgType, msg string) {
+	r.Error = append(r.Error, &ReturnError{
+		errorMsg: msg,
+		level:    level,
+		rule:     rule,
 	})
 }
 
-func parse(sql string) []*ParseResult {
+// parse: the DML statements are analyzed with `dmlOptions` if given, otherwise rejected as the other statements
+// The DML statements are checked against the tables of the catalog as changed by the DDL statements before them
+func parse(sql string, target *Target, dmlOptions *dml.ParseOptions, catalog *Catalog) []*ParseResult {
 	var results []*ParseResult
 	p := parser.New()
 
 	stmts, _, err := p.Parse(sql, "", "")
 	if err != nil {
-		result := NewParseResult(sql)
+		result := NewParseResult(sql, target)
 		result.AddError(SyntaxErr.Accept(err.Error()))
 		results = append(results, result)
 		return results
 	}
 
+	// The catalog is shared by the later checks, so the statements are applied to its copy
+	var schema *Catalog
+	if dmlOptions != nil {
+		schema = catalog.clone()
+	}
+	offset := 0
 	for _, stmt := range stmts {
-		result := NewParseResult(stmt.Text())
-		if _, ok := stmts[0].(ast.DDLNode); !ok {
+		result := NewParseResult(stmt.Text(), target)
+		result.Stmt = stmt
+		result.Fingerprint, _ = fingerprint.New(stmt)
+		pos, end := locateStmt(sql, offset, stmt.Text())
+		result.SetPosition(pos)
+		offset = end
+
+		if isRoutedDML(stmt) && dmlOptions != nil {
+			result.Kind = stmtKindDML
+			result.AddDMLResult(dml.Analyze(stmt, schema.getDMLOptions(dmlOptions)))
+		} else if _, ok := stmt.(ast.DDLNode); !ok {
+			result.Kind = stmtKindOther
 			result.AddError(NoneDDLErr)
 		} else {
+			result.Kind = stmtKindDDL
 			var ddlParser Parser
 			switch impl := stmt.(type) {
 			case *ast.CreateTableStmt:
@@ -148,6 +461,10 @@ func parse(sql string) []*ParseResult {
 				ddlParser = &UnsupportedDDLStmt{impl}
 			}
 			ddlParser.Parse(result)
+			if schema != nil {
+				// The statement does not apply is reported by the risk assessment if the catalog is loaded
+				_ = schema.Apply(stmt)
+			}
 		}
 
 		results = append(results, result)
@@ -156,6 +473,36 @@ func parse(sql string) []*ParseResult {
 	return results
 }
 
+// isRoutedDML: statement analyzed by the DML analyzer, the other DML statements such as `SELECT` are not expected in
+// the script
+func isRoutedDML(stmt ast.StmtNode) bool {
+	switch stmt.(type) {
+	case *ast.DeleteStmt, *ast.UpdateStmt, *ast.InsertStmt:
+		return true
+	}
+	return false
+}
+
+// locateStmt: return the position of the statement text searched from the offset and the offset of its end
+func locateStmt(sql string, offset int, text string) (Position, int) {
+	start := offset
+	if index := strings.Index(sql[offset:], text); index >= 0 {
+		start = offset + index
+	}
+	end := start + len(text)
+	if end > len(sql) {
+		end = len(sql)
+	}
+
+	// skip the leading spaces kept in the statement text
+	trimmed := strings.TrimLeft(sql[start:end], " \t\r\n")
+	start = end - len(trimmed)
+
+	line := strings.Count(sql[:start], "\n") + 1
+	column := start - strings.LastIndex(sql[:start], "\n")
+	return Position{Offset: start, Line: line, Column: column}, end
+}
+
 func getUnsupportedClauseErr(node ast.Node) error {
 	return UnsupportedClauseErr.Accept(restoreClause(node))
 }
@@ -169,7 +516,7 @@ func restoreClause(node ast.Node) string {
 // Rule: Table Name Definition Check
 //     1: Table name cannot contains database name
 //     2: Table name must in lower case
-//     3: Table name cannot use any of the reserved key word
+//     3: Table name cannot use any of the reserved key word of the target
 //     4: Don't include hyphen `-` in table name, use underscore `_` instead
 func checkTableNameDef(r *ParseResult, tableName string) {
 	if strings.Contains(tableName, DBNameSeparator) { // Rule 1
@@ -180,7 +527,7 @@ func checkTableNameDef(r *ParseResult, tableName string) {
 		r.AddError(TableNotLowerCaseErr.Accept(tableName))
 	}
 
-	if _, ok := reservedWords[strings.ToUpper(tableName)]; ok { // Rule 3
+	if r.Target.IsReservedWord(tableName) { // Rule 3
 		r.AddError(TableReservedWordErr.Accept(tableName))
 	}
 
@@ -1,3 +1,4 @@
+import json
 from ctypes import *
 from sys import platform
 
@@ -17,6 +18,78 @@ def parse_ddl(sql):
 
     return parser.Parse(GoString(c_char_p(sql.encode('utf-8')), len(sql))).decode('utf-8')
 
+def parse_ddl_with_options(sql, options):
+    parser = get_parser_file()
+    parser.ParseWithOptions.argtypes = [GoString, GoString]
+    parser.ParseWithOptions.restype = c_char_p
+
+    opts = json.dumps(options)
+    return parser.ParseWithOptions(GoString(c_char_p(sql.encode('utf-8')), len(sql)),
+                                   GoString(c_char_p(opts.encode('utf-8')), len(opts))).decode('utf-8')
+
+def parse_ddl_script(sql, options):
+    parser = get_parser_file()
+    parser.ParseScript.argtypes = [GoString, GoString]
+    parser.ParseScript.restype = c_char_p
+
+    opts = json.dumps(options)
+    return parser.ParseScript(GoString(c_char_p(sql.encode('utf-8')), len(sql)),
+                              GoString(c_char_p(opts.encode('utf-8')), len(opts))).decode('utf-8')
+
+def parse_mixed_script(sql, options):
+    parser = get_parser_file()
+    parser.ParseMixedScript.argtypes = [GoString, GoString]
+    parser.ParseMixedScript.restype = c_char_p
+
+    opts = json.dumps(options)
+    return parser.ParseMixedScript(GoString(c_char_p(sql.encode('utf-8')), len(sql)),
+                                   GoString(c_char_p(opts.encode('utf-8')), len(opts))).decode('utf-8')
+
+def generate_er_diagram(sql, options):
+    parser = get_parser_file()
+    parser.GenerateERDiagram.argtypes = [GoString, GoString]
+    parser.GenerateERDiagram.restype = c_char_p
+
+    opts = json.dumps(options)
+    return parser.GenerateERDiagram(GoString(c_char_p(sql.encode('utf-8')), len(sql)),
+                                    GoString(c_char_p(opts.encode('utf-8')), len(opts))).decode('utf-8')
+
+def generate_data_dictionary(sql, options):
+    parser = get_parser_file()
+    parser.GenerateDataDictionary.argtypes = [GoString, GoString]
+    parser.GenerateDataDictionary.restype = c_char_p
+
+    opts = json.dumps(options)
+    return parser.GenerateDataDictionary(GoString(c_char_p(sql.encode('utf-8')), len(sql)),
+                                         GoString(c_char_p(opts.encode('utf-8')), len(opts))).decode('utf-8')
+
+def generate_data_dictionary_from_dir(migration_dir, options):
+    parser = get_parser_file()
+    parser.GenerateDataDictionaryFromDir.argtypes = [GoString, GoString]
+    parser.GenerateDataDictionaryFromDir.restype = c_char_p
+
+    opts = json.dumps(options)
+    return parser.GenerateDataDictionaryFromDir(GoString(c_char_p(migration_dir.encode('utf-8')), len(migration_dir)),
+                                                GoString(c_char_p(opts.encode('utf-8')), len(opts))).decode('utf-8')
+
+def generate_data_dictionary_from_db(dsn, options):
+    parser = get_parser_file()
+    parser.GenerateDataDictionaryFromDB.argtypes = [GoString, GoString]
+    parser.GenerateDataDictionaryFromDB.restype = c_char_p
+
+    opts = json.dumps(options)
+    return parser.GenerateDataDictionaryFromDB(GoString(c_char_p(dsn.encode('utf-8')), len(dsn)),
+                                               GoString(c_char_p(opts.encode('utf-8')), len(opts))).decode('utf-8')
+
+def generate_go_struct(sql, options):
+    parser = get_parser_file()
+    parser.GenerateGoStruct.argtypes = [GoString, GoString]
+    parser.GenerateGoStruct.restype = c_char_p
+
+    opts = json.dumps(options)
+    return parser.GenerateGoStruct(GoString(c_char_p(sql.encode('utf-8')), len(sql)),
+                                   GoString(c_char_p(opts.encode('utf-8')), len(opts))).decode('utf-8')
+
 print(parse_ddl('''
     CREATE TABLE example (
        id BIGINT UNSIGNED AUTO_INCREMENT,
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dialect Constant
+const (
+	DialectMySQL   = "mysql"
+	DialectMariaDB = "mariadb"
+	DialectTiDB    = "tidb"
+)
+
+// Version Gated Feature Constant
+const (
+	featureCheckConstraint = "enforced `CHECK` constraint"
+	featureInstantDDL      = "`ALGORITHM=INSTANT`"
+	featureFunctionalIndex = "functional key part"
+	featureExprDefault     = "expression as `DEFAULT` value"
+	featureSequenceDefault = "sequence as `DEFAULT` value"
+	featureAutoRandom      = "`AUTO_RANDOM`"
+	featureShardRowID      = "`SHARD_ROW_ID_BITS`"
+	featurePreSplitRegion  = "`PRE_SPLIT_REGIONS`"
+)
+
+// Maximum `SHARD_ROW_ID_BITS` allowed by TiDB
+const tidbMaxShardRowIDBits = 15
+
+var (
+	dialectDisplayName = map[string]string{
+		DialectMySQL:   "MySQL",
+		DialectMariaDB: "MariaDB",
+		DialectTiDB:    "TiDB",
+	}
+
+	// Version assumed when the dialect is given without one
+	dialectDefaultVersion = map[string]string{
+		DialectMySQL:   "5.7",
+		DialectMariaDB: "10.5",
+		DialectTiDB:    "4.0",
+	}
+
+	// Minimum version of each dialect supporting the feature, dialect not listed does not support it at all
+	featureMinVersion = map[string]map[string]Version{
+		featureCheckConstraint: {
+			DialectMySQL:   {8, 0, 16},
+			DialectMariaDB: {10, 2, 1},
+		},
+		featureInstantDDL: {
+			DialectMySQL:   {8, 0, 12},
+			DialectMariaDB: {10, 3, 7},
+			DialectTiDB:    {2, 1, 0},
+		},
+		featureFunctionalIndex: {
+			DialectMySQL: {8, 0, 13},
+		},
+		featureExprDefault: {
+			DialectMySQL:   {8, 0, 13},
+			DialectMariaDB: {10, 2, 1},
+		},
+		featureSequenceDefault: {
+			DialectMariaDB: {10, 3, 0},
+			DialectTiDB:    {4, 0, 0},
+		},
+		featureAutoRandom: {
+			DialectTiDB: {3, 1, 0},
+		},
+		featureShardRowID: {
+			DialectTiDB: {2, 0, 0},
+		},
+		featurePreSplitRegion: {
+			DialectTiDB: {3, 0, 0},
+		},
+	}
+)
+
+var (
+	// Reserved words removed in MySQL 8.0
+	reservedWordsMySQL80Removed = []string{
+		"ANALYSE", "DES_KEY_FILE", "PARSE_GCOL_EXPR", "REDOFILE", "SQL_CACHE",
+	}
+
+	// Reserved words added in MySQL 8.0
+	reservedWordsMySQL80Added = []string{
+		"CUBE", "CUME_DIST", "DENSE_RANK", "EMPTY", "EXCEPT", "FIRST_VALUE", "FUNCTION", "GROUPING", "GROUPS",
+		"JSON_TABLE", "LAG", "LAST_VALUE", "LATERAL", "LEAD", "NTH_VALUE", "NTILE", "OF", "OVER", "PERCENT_RANK",
+		"RANK", "RECURSIVE", "ROW", "ROWS", "ROW_NUMBER", "SYSTEM", "WINDOW",
+	}
+
+	// MySQL 5.7 reserved words which are not reserved in MariaDB
+	reservedWordsMariaDBRemoved = []string{
+		"GENERATED", "GET", "IO_AFTER_GTIDS", "IO_BEFORE_GTIDS", "MASTER_BIND", "OPTIMIZER_COSTS", "STORED",
+		"VIRTUAL",
+	}
+
+	// Reserved words in MariaDB which are not reserved in MySQL 5.7
+	reservedWordsMariaDBAdded = []string{
+		"CURRENT_ROLE", "DELETE_DOMAIN_ID", "DO_DOMAIN_IDS", "EXCEPT", "GENERAL", "IGNORE_DOMAIN_IDS",
+		"IGNORE_SERVER_IDS", "INTERSECT", "MASTER_HEARTBEAT_PERIOD", "OFFSET", "OVER", "PAGE_CHECKSUM",
+		"PARSE_VCOL_EXPR", "POSITION", "RECURSIVE", "REF_SYSTEM_ID", "RETURNING", "ROWS", "ROW_NUMBER", "SLOW",
+		"WINDOW",
+	}
+
+	// Reserved words in TiDB which are not reserved in MySQL 5.7
+	reservedWordsTiDBAdded = []string{
+		"CUME_DIST", "DENSE_RANK", "EXCEPT", "FIRST_VALUE", "GROUPS", "LAG", "LAST_VALUE", "LEAD", "NTH_VALUE",
+		"NTILE", "OVER", "PERCENT_RANK", "RANK", "ROW", "ROWS", "ROW_NUMBER", "WINDOW",
+	}
+)
+
+// Version: server version in `major.minor.patch`, missing patch refers to the latest release of the series
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// latestPatch is used when the version only specifies `major.minor`
+const latestPatch = 1<<31 - 1
+
+func ParseVersion(version string) (Version, error) {
+	v := Version{Patch: latestPatch}
+	tokens := strings.Split(strings.TrimSpace(version), ".")
+	if len(tokens) < 2 || len(tokens) > 3 {
+		return v, fmt.Errorf("invalid version `%s`, expect `major.minor[.patch]`", version)
+	}
+
+	fields := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, token := range tokens {
+		num, err := strconv.Atoi(token)
+		if err != nil || num < 0 {
+			return v, fmt.Errorf("invalid version `%s`, expect `major.minor[.patch]`", version)
+		}
+		*fields[i] = num
+	}
+	return v, nil
+}
+
+func (v Version) AtLeast(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
+	}
+	return v.Patch >= other.Patch
+}
+
+func (v Version) String() string {
+	if v.Patch == latestPatch {
+		return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Target: the database dialect and version which the DDL will be executed on
+type Target struct {
+	Dialect string
+	Version Version
+
+	// TiDB only, primary key is always non-clustered when `alter-primary-key` is enabled
+	AlterPrimaryKey bool
+
+	reservedWords map[string]struct{}
+}
+
+func NewTarget(dialect string, version string) (*Target, error) {
+	dialect = strings.ToLower(strings.TrimSpace(dialect))
+	if dialect == "" {
+		dialect = DialectMySQL
+	}
+	if _, ok := dialectDisplayName[dialect]; !ok {
+		return nil, fmt.Errorf("unknown dialect `%s`, expect one of `%s`, `%s` or `%s`", dialect, DialectMySQL,
+			DialectMariaDB, DialectTiDB)
+	}
+	if strings.TrimSpace(version) == "" {
+		version = dialectDefaultVersion[dialect]
+	}
+
+	v, err := ParseVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	if dialect == DialectMySQL && v.Major != 5 && v.Major != 8 {
+		return nil, fmt.Errorf("unsupported MySQL version `%s`, expect 5.7 or 8.0", version)
+	}
+
+	return &Target{
+		Dialect:       dialect,
+		Version:       v,
+		reservedWords: getReservedWords(dialect, v),
+	}, nil
+}
+
+// DefaultTarget: MySQL 5.7, the target before dialect selection is introduced
+func DefaultTarget() *Target {
+	target, _ := NewTarget(DialectMySQL, "")
+	return target
+}
+
+func (t *Target) String() string {
+	return dialectDisplayName[t.Dialect] + " " + t.Version.String()
+}
+
+func (t *Target) IsReservedWord(word string) bool {
+	_, ok := t.reservedWords[strings.ToUpper(word)]
+	return ok
+}
+
+// Supports: return if the version gated feature is supported by the target
+func (t *Target) Supports(feature string) bool {
+	minVersion, ok := featureMinVersion[feature][t.Dialect]
+	return ok && t.Version.AtLeast(minVersion)
+}
+
+// Return the reserved words of the given dialect and version based on MySQL 5.7
+func getReservedWords(dialect string, version Version) map[string]struct{} {
+	var removed, added []string
+	switch dialect {
+	case DialectMySQL:
+		if version.Major >= 8 {
+			removed, added = reservedWordsMySQL80Removed, reservedWordsMySQL80Added
+		}
+	case DialectMariaDB:
+		removed, added = reservedWordsMariaDBRemoved, reservedWordsMariaDBAdded
+	case DialectTiDB:
+		added = reservedWordsTiDBAdded
+	}
+
+	words := make(map[string]struct{}, len(reservedWords)+len(added))
+	for word := range reservedWords {
+		words[word] = struct{}{}
+	}
+	for _, word := range removed {
+		delete(words, word)
+	}
+	for _, word := range added {
+		words[word] = struct{}{}
+	}
+	return words
+}
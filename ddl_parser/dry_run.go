@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"github.com/pingcap/log"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/terror"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/session"
+	"github.com/pingcap/tidb/store/mockstore"
+	"go.uber.org/zap/zapcore"
+)
+
+// Database the schema is replayed and the statements are run in during dry run
+const dryRunDatabase = "dry_run"
+
+// DryRunner: embedded TiDB on the in-memory mock store, nothing is written to the real database
+// The errors are reported by TiDB, features TiDB does not support are reported as `DryRunUnsupportedErr`
+type DryRunner struct {
+	store   kv.Storage
+	dom     *domain.Domain
+	session session.Session
+}
+
+func NewDryRunner() (*DryRunner, error) {
+	log.SetLevel(zapcore.FatalLevel)
+
+	store, err := mockstore.NewMockTikvStore()
+	if err != nil {
+		return nil, err
+	}
+	runner := &DryRunner{store: store}
+	if runner.dom, err = session.BootstrapSession(store); err != nil {
+		runner.Close()
+		return nil, err
+	}
+	if runner.session, err = session.CreateSession(store); err != nil {
+		runner.Close()
+		return nil, err
+	}
+	for _, sql := range []string{"CREATE DATABASE " + dryRunDatabase, "USE " + dryRunDatabase} {
+		if err := runner.Execute(sql); err != nil {
+			runner.Close()
+			return nil, err
+		}
+	}
+	return runner, nil
+}
+
+// LoadCatalog: replay the table DDL the catalog is built from
+func (d *DryRunner) LoadCatalog(catalog *Catalog) error {
+	if catalog == nil {
+		return nil
+	}
+	for _, sql := range catalog.statements {
+		if err := d.Execute(sql); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *DryRunner) Execute(sql string) error {
+	_, err := d.session.Execute(context.Background(), sql)
+	return err
+}
+
+func (d *DryRunner) Close() {
+	if d.session != nil {
+		d.session.Close()
+	}
+	if d.dom != nil {
+		d.dom.Close()
+	}
+	d.store.Close()
+}
+
+// dryRun: run the DDL statements in order on the schema of the catalog, the execution error is added to the result
+// Dry run is skipped with warning on the first result if TiDB cannot start or the schema cannot be replayed
+func dryRun(results []*ParseResult, catalog *Catalog) {
+	if len(results) == 0 {
+		return
+	}
+	runner, err := NewDryRunner()
+	if err != nil {
+		results[0].AddError(DryRunSkippedErr.Accept(err.Error()))
+		return
+	}
+	defer runner.Close()
+	if err := runner.LoadCatalog(catalog); err != nil {
+		results[0].AddError(DryRunSkippedErr.Accept(err.Error()))
+		return
+	}
+
+	for _, r := range results {
+		if _, ok := r.Stmt.(ast.DDLNode); !ok {
+			continue
+		}
+		if err := runner.Execute(r.Stmt.Text()); err != nil {
+			if getErrorCode(err) == errno.ErrUnsupportedDDLOperation {
+				r.AddError(DryRunUnsupportedErr.Accept(err.Error()))
+			} else {
+				r.AddError(DryRunErr.Accept(err.Error()))
+			}
+		}
+	}
+}
+
+// getErrorCode: return the MySQL error code of the TiDB error, 0 if unknown
+func getErrorCode(err error) uint16 {
+	for err != nil {
+		if impl, ok := err.(*terror.Error); ok {
+			return uint16(impl.Code())
+		}
+		cause, ok := err.(interface{ Cause() error })
+		if !ok || cause.Cause() == err {
+			break
+		}
+		err = cause.Cause()
+	}
+	return 0
+}
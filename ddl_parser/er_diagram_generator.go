@@ -0,0 +1,246 @@
+package main
+
+import "C"
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pingcap/parser"
+	"github.com/pingcap/parser/ast"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Diagram Format Constant
+const (
+	DiagramFormatMermaid = "mermaid"
+	DiagramFormatDOT     = "dot"
+)
+
+// Suffix of the column referring to column `id` of another table, e.g. `user_id` refers to `user`.`id`
+const refColumnSuffix = NameSeparator + columnID
+
+var invalidDiagramNameRegexp = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// ERDiagramOptions: options accepted by `GenerateERDiagram` in JSON format
+type ERDiagramOptions struct {
+	Format string `json:"format"` // `mermaid` or `dot`, default to `mermaid`
+}
+
+type ERDiagramResult struct {
+	Format  string   `json:"format"`
+	Diagram string   `json:"diagram"`
+	Error   []string `json:"error_msg"`
+}
+
+// Relationship: inferred reference from `Table`.`Column` to `RefTable`.`id`
+type Relationship struct {
+	Table    string
+	Column   string
+	RefTable string
+	Nullable bool
+}
+
+//export GenerateERDiagram
+func GenerateERDiagram(sql string, options string) *C.char {
+	jsonStr, _ := json.Marshal(generateERDiagram(sql, options))
+	return stringToCString(string(jsonStr))
+}
+
+// generateERDiagram: generate the ER diagram of all tables created in the sql, other statements are ignored
+func generateERDiagram(sql string, options string) *ERDiagramResult {
+	opts := &ERDiagramOptions{}
+	if strings.TrimSpace(options) != "" {
+		if err := json.Unmarshal([]byte(options), opts); err != nil {
+			return &ERDiagramResult{Error: []string{InvalidOptionErr.Accept(err.Error()).Error()}}
+		}
+	}
+	if opts.Format == "" {
+		opts.Format = DiagramFormatMermaid
+	}
+	result := &ERDiagramResult{Format: opts.Format}
+	if opts.Format != DiagramFormatMermaid && opts.Format != DiagramFormatDOT {
+		result.Error = append(result.Error, InvalidOptionErr.Accept(fmt.Sprintf("unknown diagram format `%s`, "+
+			"expect `%s` or `%s`", opts.Format, DiagramFormatMermaid, DiagramFormatDOT)).Error())
+		return result
+	}
+
+	stmts, _, err := parser.New().Parse(sql, "", "")
+	if err != nil {
+		result.Error = append(result.Error, SyntaxErr.Accept(err.Error()).Error())
+		return result
+	}
+
+	var tables []*Table
+	for _, stmt := range stmts {
+		if createStmt, ok := stmt.(*ast.CreateTableStmt); ok {
+			tables = append(tables, buildTableModel(createStmt))
+		}
+	}
+
+	if opts.Format == DiagramFormatDOT {
+		result.Diagram = generateDOTDiagram(tables)
+	} else {
+		result.Diagram = generateMermaidDiagram(tables)
+	}
+	return result
+}
+
+func generateMermaidDiagram(tables []*Table) string {
+	relationships := inferRelationships(tables)
+	var sb strings.Builder
+	sb.WriteString("erDiagram\n")
+	for _, table := range tables {
+		sb.WriteString(fmt.Sprintf("    %s {\n", toDiagramName(table.Name)))
+		for _, col := range table.Columns {
+			sb.WriteString(fmt.Sprintf("        %s %s", strings.ToLower(toDiagramName(col.Type)), toDiagramName(col.Name)))
+			if keys := getKeyMarkers(table, col.Name, relationships); len(keys) != 0 {
+				sb.WriteString(" " + strings.Join(keys, ", "))
+			}
+			if indexes := getIndexNames(table, col.Name); len(indexes) != 0 {
+				sb.WriteString(fmt.Sprintf(" \"%s\"", strings.Join(indexes, ", ")))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("    }\n")
+	}
+
+	for _, rel := range relationships {
+		cardinality := "||--o{"
+		if rel.Nullable {
+			cardinality = "|o--o{"
+		}
+		sb.WriteString(fmt.Sprintf("    %s %s %s : \"%s\"\n", toDiagramName(rel.RefTable), cardinality,
+			toDiagramName(rel.Table), rel.Column))
+	}
+	return sb.String()
+}
+
+func generateDOTDiagram(tables []*Table) string {
+	relationships := inferRelationships(tables)
+	var sb strings.Builder
+	sb.WriteString("digraph schema {\n")
+	sb.WriteString("    rankdir=LR;\n")
+	sb.WriteString("    node [shape=plaintext];\n")
+	for _, table := range tables {
+		sb.WriteString(fmt.Sprintf("    \"%s\" [label=<<TABLE BORDER=\"0\" CELLBORDER=\"1\" CELLSPACING=\"0\">\n",
+			table.Name))
+		sb.WriteString(fmt.Sprintf("        <TR><TD COLSPAN=\"3\"><B>%s</B></TD></TR>\n", html.EscapeString(table.Name)))
+		for _, col := range table.Columns {
+			sb.WriteString(fmt.Sprintf("        <TR><TD PORT=\"%s\" ALIGN=\"LEFT\">%s</TD><TD ALIGN=\"LEFT\">%s</TD><TD>%s</TD></TR>\n",
+				html.EscapeString(col.Name), html.EscapeString(col.Name), html.EscapeString(col.ColumnType),
+				strings.Join(getKeyMarkers(table, col.Name, relationships), ", ")))
+		}
+		for _, index := range table.Indexes {
+			if index.Type == IndexTypePrimary || index.Type == IndexTypeCheck {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("        <TR><TD COLSPAN=\"3\" ALIGN=\"LEFT\"><I>%s %s (%s)</I></TD></TR>\n",
+				index.Type, html.EscapeString(index.Name), html.EscapeString(getIndexColumnNames(index))))
+		}
+		sb.WriteString("    </TABLE>>];\n")
+	}
+
+	for _, rel := range relationships {
+		style := "solid"
+		if rel.Nullable {
+			style = "dashed"
+		}
+		sb.WriteString(fmt.Sprintf("    \"%s\":\"%s\" -> \"%s\":\"%s\" [arrowhead=tee, arrowtail=crow, dir=both, style=%s];\n",
+			rel.Table, rel.Column, rel.RefTable, columnID, style))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// inferRelationships: infer relationship from column `<table>_id` to `<table>`.`id`, as `FOREIGN KEY` is not allowed
+func inferRelationships(tables []*Table) []*Relationship {
+	tableWithID := make(map[string]string)
+	for _, table := range tables {
+		if table.GetColumn(columnID) != nil {
+			tableWithID[strings.ToLower(table.Name)] = table.Name
+		}
+	}
+
+	var relationships []*Relationship
+	for _, table := range tables {
+		for _, col := range table.Columns {
+			colName := strings.ToLower(col.Name)
+			if !strings.HasSuffix(colName, refColumnSuffix) {
+				continue
+			}
+			if refTable, ok := tableWithID[strings.TrimSuffix(colName, refColumnSuffix)]; ok {
+				relationships = append(relationships, &Relationship{
+					Table:    table.Name,
+					Column:   col.Name,
+					RefTable: refTable,
+					Nullable: col.Nullable,
+				})
+			}
+		}
+	}
+	return relationships
+}
+
+// Return `PK`, `UK` and `FK` markers of the column
+func getKeyMarkers(table *Table, colName string, relationships []*Relationship) []string {
+	var markers []string
+	hasPrimary, hasUnique := false, false
+	for _, index := range table.Indexes {
+		for _, indexCol := range index.Columns {
+			if indexCol.Name != colName {
+				continue
+			}
+			hasPrimary = hasPrimary || index.Type == IndexTypePrimary
+			hasUnique = hasUnique || index.Type == IndexTypeUnique
+		}
+	}
+	if hasPrimary {
+		markers = append(markers, "PK")
+	}
+	for _, rel := range relationships {
+		if rel.Table == table.Name && rel.Column == colName {
+			markers = append(markers, "FK")
+		}
+	}
+	if hasUnique {
+		markers = append(markers, "UK")
+	}
+	return markers
+}
+
+// Return the names of the non-unique indexes containing the column
+func getIndexNames(table *Table, colName string) []string {
+	var names []string
+	for _, index := range table.Indexes {
+		if index.Type == IndexTypePrimary || index.Type == IndexTypeUnique || index.Type == IndexTypeCheck {
+			continue
+		}
+		for _, indexCol := range index.Columns {
+			if indexCol.Name == colName {
+				names = append(names, index.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+func getIndexColumnNames(index *Index) string {
+	var names []string
+	for _, indexCol := range index.Columns {
+		if indexCol.Name != "" && indexCol.Length != nil {
+			names = append(names, fmt.Sprintf("%s(%d)", indexCol.Name, *indexCol.Length))
+		} else if indexCol.Name != "" {
+			names = append(names, indexCol.Name)
+		} else {
+			names = append(names, "("+indexCol.Expr+")")
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// Replace the characters not allowed in mermaid entity and attribute name with `_`
+func toDiagramName(name string) string {
+	return invalidDiagramNameRegexp.ReplaceAllString(name, NameSeparator)
+}
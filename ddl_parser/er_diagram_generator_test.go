@@ -0,0 +1,98 @@
+package main
+
+import (
+	"github.com/pingcap/parser"
+	"github.com/pingcap/parser/ast"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Cases of the ER diagram, the options are given in the leading comment of the case, e.g.
+// `-- options: {"format": "dot"}`, and the diagram is compared with the `.mmd` or `.dot` golden file
+const erDiagramGoldenDir = "testdata/er_diagram"
+
+func TestERDiagramGolden(t *testing.T) {
+	for _, sqlFile := range getGoldenFiles(t, erDiagramGoldenDir) {
+		sqlFile := sqlFile
+		t.Run(strings.TrimSuffix(filepath.Base(sqlFile), ".sql"), func(t *testing.T) {
+			content, err := ioutil.ReadFile(sqlFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sql := string(content)
+			result := generateERDiagram(sql, getGoldenOptions(sql))
+			if len(result.Error) != 0 {
+				t.Fatalf("unexpected error %v", result.Error)
+			}
+
+			ext := ".mmd"
+			if result.Format == DiagramFormatDOT {
+				ext = ".dot"
+			}
+			checkGoldenFile(t, strings.TrimSuffix(sqlFile, ".sql")+ext, []byte(result.Diagram))
+		})
+	}
+}
+
+// TestInferRelationships: only column `<table>_id` referring to a table with column `id` is a relationship
+func TestInferRelationships(t *testing.T) {
+	for _, c := range []struct {
+		sql           string
+		relationships []Relationship
+	}{
+		{
+			"CREATE TABLE user (id BIGINT); CREATE TABLE `order` (id BIGINT, user_id BIGINT NOT NULL);",
+			[]Relationship{{Table: "order", Column: "user_id", RefTable: "user"}},
+		},
+		{
+			"CREATE TABLE User (id BIGINT); CREATE TABLE `order` (id BIGINT, USER_ID BIGINT NULL);",
+			[]Relationship{{Table: "order", Column: "USER_ID", RefTable: "User", Nullable: true}},
+		},
+		{
+			"CREATE TABLE category (id BIGINT); CREATE TABLE category_tree (category_id BIGINT, parent_category_id BIGINT);",
+			[]Relationship{{Table: "category_tree", Column: "category_id", RefTable: "category", Nullable: true}},
+		},
+		{"CREATE TABLE tag (name VARCHAR(64)); CREATE TABLE post (id BIGINT, tag_id BIGINT);", nil},
+		{"CREATE TABLE post (id BIGINT, author_id BIGINT, user_uid BIGINT);", nil},
+	} {
+		stmts, _, err := parser.New().Parse(c.sql, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var tables []*Table
+		for _, stmt := range stmts {
+			tables = append(tables, buildTableModel(stmt.(*ast.CreateTableStmt)))
+		}
+
+		relationships := inferRelationships(tables)
+		if len(relationships) != len(c.relationships) {
+			t.Errorf("expect relationships %+v of %s, got %d", c.relationships, c.sql, len(relationships))
+			continue
+		}
+		for i, rel := range relationships {
+			if *rel != c.relationships[i] {
+				t.Errorf("expect relationship %+v of %s, got %+v", c.relationships[i], c.sql, *rel)
+			}
+		}
+	}
+}
+
+// TestERDiagramError: the errors of the options and the syntax are reported without diagram
+func TestERDiagramError(t *testing.T) {
+	for _, c := range []struct {
+		sql     string
+		options string
+		err     string
+	}{
+		{"CREATE TABLE example (id BIGINT);", `{"format": "png"}`, "unknown diagram format `png`"},
+		{"CREATE TABLE example (id BIGINT);", `{"format": `, "invalid parse options"},
+		{"CREATE TABLE example (id BIGINT", "", "syntax error"},
+	} {
+		result := generateERDiagram(c.sql, c.options)
+		if len(result.Error) != 1 || !strings.Contains(result.Error[0], c.err) || result.Diagram != "" {
+			t.Errorf("expect error %q for %s with options %s, got %+v", c.err, c.sql, c.options, result)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+//go:build go1.18
+// +build go1.18
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/sidai/parser/dml"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// FuzzParse: the golden cases are used as the seed corpus, run with `go test -fuzz FuzzParse`
+// Parse must not panic, must be deterministic and all findings must carry the position of the statement, with or
+// without the DML statements routed to the DML analyzer
+func FuzzParse(f *testing.F) {
+	for _, sqlFile := range getGoldenCases(f) {
+		content, err := ioutil.ReadFile(sqlFile)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(string(content))
+	}
+	// Statements leaving optional fields of the AST empty
+	for _, sql := range []string{
+		"ALTER TABLE example ALTER COLUMN name SET DEFAULT 'name', ALTER COLUMN code DROP DEFAULT;",
+		"ALTER TABLE example ADD COLUMN (name VARCHAR(64), code VARCHAR(64)), ADD COLUMN remark TEXT FIRST;",
+		"CREATE TABLE example (id BIGINT, KEY index_created_at ((id + 1)), PRIMARY KEY ((id * 2)));",
+		"CREATE TABLE example (id BIGINT AUTO_RANDOM, PRIMARY KEY (id)) PARTITION BY KEY() PARTITIONS 2;",
+		"CREATE TABLE example LIKE example_old; CREATE TABLE example_new SELECT 1;",
+	} {
+		f.Add(sql)
+	}
+
+	f.Fuzz(func(t *testing.T, sql string) {
+		// The DML statements are rejected without the DML options and routed to the DML analyzer with them
+		for _, dmlOptions := range []*dml.ParseOptions{nil, {}} {
+			first, err := json.Marshal(toReturnResults(parse(sql, DefaultTarget(), dmlOptions, nil)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			results := toReturnResults(parse(sql, DefaultTarget(), dmlOptions, nil))
+			second, err := json.Marshal(results)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(first, second) {
+				t.Fatalf("non-deterministic output\nfirst:\n%s\nsecond:\n%s", first, second)
+			}
+
+			for _, result := range results {
+				checkPosition(t, sql, result.Position)
+				for _, finding := range result.Findings {
+					if finding.Position != result.Position {
+						t.Fatalf("finding %s at %+v, expect %+v", finding.Rule, finding.Position, result.Position)
+					}
+				}
+			}
+		}
+	})
+}
+
+func toReturnResults(parseResults []*ParseResult) []*ReturnResult {
+	var results []*ReturnResult
+	for _, r := range parseResults {
+		results = append(results, r.toReturnResult())
+	}
+	return results
+}
+
+// checkPosition: the position must be within the sql with line and column matching the offset
+func checkPosition(t *testing.T, sql string, pos Position) {
+	if pos.Offset < 0 || pos.Offset > len(sql) {
+		t.Fatalf("offset %d out of range [0, %d]", pos.Offset, len(sql))
+	}
+	line := strings.Count(sql[:pos.Offset], "\n") + 1
+	column := pos.Offset - strings.LastIndex(sql[:pos.Offset], "\n")
+	if pos.Line != line || pos.Column != column {
+		t.Fatalf("position %+v, expect line %d column %d", pos, line, column)
+	}
+}
@@ -0,0 +1,326 @@
+package main
+
+import "C"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/pingcap/parser"
+	"github.com/pingcap/parser/ast"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// Nullable Style Constant
+const (
+	NullableStyleSQL     = "sql"
+	NullableStylePointer = "pointer"
+)
+
+// Struct Tag Constant
+const (
+	TagDB   = "db"
+	TagGorm = "gorm"
+	TagJSON = "json"
+)
+
+const defaultGoStructTemplate = `// Code generated from CREATE TABLE {{.TableName}}. DO NOT EDIT.
+
+package {{.Package}}
+{{if .Imports}}
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{end}}
+{{if .Comment}}// {{.StructName}} {{.Comment}}
+{{else}}// {{.StructName}} maps to table {{.TableName}}
+{{end -}}
+type {{.StructName}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`{{.Tag}}`" + `{{if .Comment}} // {{.Comment}}{{end}}
+{{- end}}
+}
+
+// TableName returns the table name of {{.StructName}}
+func ({{.StructName}}) TableName() string {
+	return "{{.TableName}}"
+}
+`
+
+var (
+	GoStructNotCreateTableErr = errors.New("go struct can only be generated from `CREATE TABLE`")
+	GoStructNotValidatedErr   = errors.New("go struct can only be generated from `CREATE TABLE` without error")
+
+	// Common initialisms kept in upper case in field name
+	goInitialisms = map[string]struct{}{
+		"API": {}, "CPU": {}, "DNS": {}, "HTML": {}, "HTTP": {}, "ID": {}, "IP": {}, "JSON": {}, "SQL": {},
+		"UID": {}, "URI": {}, "URL": {}, "UTF8": {}, "UUID": {}, "XML": {},
+	}
+)
+
+// GoStructOptions: options accepted by `GenerateGoStruct` in JSON format
+type GoStructOptions struct {
+	ParseOptions
+	Package  string   `json:"package"`  // Package name of the generated file, default to `model`
+	Nullable string   `json:"nullable"` // Type used for nullable column, `sql` for `sql.NullXXX` or `pointer`
+	Tags     []string `json:"tags"`     // Struct tags to generate, subset of `db`, `gorm` and `json`, default to all
+	Template string   `json:"template"` // Custom `text/template` replacing the default template
+}
+
+// GoStructData: data passed to the template
+type GoStructData struct {
+	Package    string
+	Imports    []string
+	StructName string
+	TableName  string
+	Comment    string
+	Fields     []*GoStructField
+	Table      *Table
+}
+
+type GoStructField struct {
+	Name    string
+	Type    string
+	Tag     string
+	Comment string
+	Column  *Column
+}
+
+type GoStructResult struct {
+	SQL   string   `json:"sql"`
+	Table string   `json:"table"`
+	Code  string   `json:"code"`
+	Error []string `json:"error_msg"`
+}
+
+//export GenerateGoStruct
+func GenerateGoStruct(sql string, options string) *C.char {
+	jsonStr, _ := json.Marshal(generateGoStructs(sql, options))
+	return stringToCString(string(jsonStr))
+}
+
+func NewGoStructOptions(options string) (*GoStructOptions, error) {
+	opts := &GoStructOptions{}
+	if strings.TrimSpace(options) != "" {
+		if err := json.Unmarshal([]byte(options), opts); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Package == "" {
+		opts.Package = "model"
+	}
+	if opts.Nullable == "" {
+		opts.Nullable = NullableStyleSQL
+	}
+	if opts.Nullable != NullableStyleSQL && opts.Nullable != NullableStylePointer {
+		return nil, fmt.Errorf("unknown nullable style `%s`, expect `%s` or `%s`", opts.Nullable,
+			NullableStyleSQL, NullableStylePointer)
+	}
+	if len(opts.Tags) == 0 {
+		opts.Tags = []string{TagDB, TagGorm, TagJSON}
+	}
+	if opts.Template == "" {
+		opts.Template = defaultGoStructTemplate
+	}
+	return opts, nil
+}
+
+// generateGoStructs: generate go struct for each `CREATE TABLE` statement passing the validation
+func generateGoStructs(sql string, options string) []*GoStructResult {
+	var target *Target
+	opts, err := NewGoStructOptions(options)
+	if err == nil {
+		target, err = opts.ParseOptions.Target()
+	}
+	if err != nil {
+		return []*GoStructResult{{SQL: sql, Error: []string{InvalidOptionErr.Accept(err.Error()).Error()}}}
+	}
+
+	stmts, _, err := parser.New().Parse(sql, "", "")
+	if err != nil {
+		return []*GoStructResult{{SQL: sql, Error: []string{SyntaxErr.Accept(err.Error()).Error()}}}
+	}
+
+	var results []*GoStructResult
+	for _, stmt := range stmts {
+		result := &GoStructResult{SQL: stmt.Text()}
+		createStmt, ok := stmt.(*ast.CreateTableStmt)
+		if !ok {
+			result.Error = append(result.Error, GoStructNotCreateTableErr.Error())
+			results = append(results, result)
+			continue
+		}
+
+		parseResult := NewParseResult(stmt.Text(), target)
+		(&CreateTableStmt{createStmt}).Parse(parseResult)
+		result.Table = parseResult.OldTable
+		if errs := parseResult.toReturnResult().Error; len(errs) != 0 {
+			result.Error = append([]string{GoStructNotValidatedErr.Error()}, errs...)
+		} else if result.Code, err = generateGoStruct(createStmt, opts); err != nil {
+			result.Error = append(result.Error, err.Error())
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// generateGoStruct: render the go struct of the validated `CREATE TABLE` statement with the template
+func generateGoStruct(stmt *ast.CreateTableStmt, opts *GoStructOptions) (string, error) {
+	tmpl, err := template.New("go_struct").Parse(opts.Template)
+	if err != nil {
+		return "", err
+	}
+
+	data := buildGoStructData(buildTableModel(stmt), opts)
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("generated code is not valid go source: %s", err.Error())
+	}
+	return string(code), nil
+}
+
+func buildGoStructData(table *Table, opts *GoStructOptions) *GoStructData {
+	data := &GoStructData{
+		Package:    opts.Package,
+		StructName: toGoName(table.Name[strings.LastIndex(table.Name, DBNameSeparator)+1:]),
+		TableName:  table.Name,
+		Table:      table,
+	}
+	for _, option := range table.Options {
+		if option.Name == "comment" {
+			data.Comment = option.Value
+		}
+	}
+
+	primaryCols := make(map[string]struct{})
+	for _, index := range table.Indexes {
+		if index.Type == IndexTypePrimary {
+			for _, indexCol := range index.Columns {
+				primaryCols[indexCol.Name] = struct{}{}
+			}
+		}
+	}
+
+	imports := make(map[string]struct{})
+	for _, col := range table.Columns {
+		goType, pkg := getGoType(col, opts.Nullable)
+		if pkg != "" {
+			imports[pkg] = struct{}{}
+		}
+		_, isPrimary := primaryCols[col.Name]
+		data.Fields = append(data.Fields, &GoStructField{
+			Name:    toGoName(col.Name),
+			Type:    goType,
+			Tag:     getGoStructTag(col, isPrimary, opts.Tags),
+			Comment: strings.Replace(col.Comment, "\n", " ", -1),
+			Column:  col,
+		})
+	}
+
+	for pkg := range imports {
+		data.Imports = append(data.Imports, pkg)
+	}
+	sort.Strings(data.Imports)
+	return data
+}
+
+// Return the go type and the package to import for the column
+func getGoType(col *Column, nullable string) (string, string) {
+	goType, pkg := "string", ""
+	switch col.Type {
+	case "TINYINT":
+		goType = "int8"
+	case "SMALLINT", "YEAR":
+		goType = "int16"
+	case "MEDIUMINT", "INT":
+		goType = "int32"
+	case "BIGINT":
+		goType = "int64"
+	case "FLOAT":
+		goType = "float32"
+	case "DOUBLE":
+		goType = "float64"
+	case "DATE", "DATETIME", "TIMESTAMP":
+		goType, pkg = "time.Time", "time"
+	case "BINARY", "VARBINARY", "TINYBLOB", "BLOB", "MEDIUMBLOB", "LONGBLOB", "BIT", "GEOMETRY":
+		return "[]byte", ""
+	}
+	if col.Unsigned && strings.HasPrefix(goType, "int") {
+		goType = "u" + goType
+	}
+	if !col.Nullable {
+		return goType, pkg
+	}
+
+	if nullable == NullableStyleSQL {
+		switch goType {
+		case "string":
+			return "sql.NullString", "database/sql"
+		case "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32":
+			return "sql.NullInt64", "database/sql"
+		case "float32", "float64":
+			return "sql.NullFloat64", "database/sql"
+		case "time.Time":
+			return "sql.NullTime", "database/sql"
+		}
+	}
+	return "*" + goType, pkg
+}
+
+func getGoStructTag(col *Column, isPrimary bool, tags []string) string {
+	var tokens []string
+	for _, tag := range tags {
+		switch tag {
+		case TagDB:
+			tokens = append(tokens, fmt.Sprintf(`db:"%s"`, col.Name))
+		case TagJSON:
+			tokens = append(tokens, fmt.Sprintf(`json:"%s"`, col.Name))
+		case TagGorm:
+			gormTokens := []string{"column:" + col.Name, "type:" + col.ColumnType}
+			if isPrimary {
+				gormTokens = append(gormTokens, "primaryKey")
+			}
+			if col.AutoIncrement {
+				gormTokens = append(gormTokens, "autoIncrement")
+			}
+			if !col.Nullable {
+				gormTokens = append(gormTokens, "not null")
+			}
+			tokens = append(tokens, fmt.Sprintf(`gorm:"%s"`, strings.Join(gormTokens, ";")))
+		}
+	}
+	return strings.Join(tokens, " ")
+}
+
+// toGoName: convert snake case name to exported camel case name, e.g. `user_id` to `UserID`
+func toGoName(name string) string {
+	var sb strings.Builder
+	for _, token := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if _, ok := goInitialisms[strings.ToUpper(token)]; ok {
+			sb.WriteString(strings.ToUpper(token))
+			continue
+		}
+		runes := []rune(strings.ToLower(token))
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+
+	goName := sb.String()
+	if goName == "" || unicode.IsDigit([]rune(goName)[0]) {
+		goName = "X" + goName
+	}
+	return goName
+}
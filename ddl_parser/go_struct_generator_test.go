@@ -0,0 +1,83 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Cases of the go struct, the options are given in the leading comment of the case, e.g.
+// `-- options: {"nullable": "pointer"}`, and the code or the errors of each statement are compared with the `.golden`
+// file next to it
+const goStructGoldenDir = "testdata/go_struct"
+
+func TestGoStructGolden(t *testing.T) {
+	for _, sqlFile := range getGoldenFiles(t, goStructGoldenDir) {
+		sqlFile := sqlFile
+		t.Run(strings.TrimSuffix(filepath.Base(sqlFile), ".sql"), func(t *testing.T) {
+			content, err := ioutil.ReadFile(sqlFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sql := string(content)
+
+			var sb strings.Builder
+			for _, result := range generateGoStructs(sql, getGoldenOptions(sql)) {
+				sb.WriteString("// table: " + result.Table + "\n")
+				for _, err := range result.Error {
+					sb.WriteString("// error: " + err + "\n")
+				}
+				sb.WriteString(result.Code)
+			}
+			checkGoldenFile(t, strings.TrimSuffix(sqlFile, ".sql")+".golden", []byte(sb.String()))
+		})
+	}
+}
+
+func TestToGoName(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		goName string
+	}{
+		{"id", "ID"},
+		{"user_id", "UserID"},
+		{"api_url", "APIURL"},
+		{"created_at", "CreatedAt"},
+		{"UPDATED_AT", "UpdatedAt"},
+		{"order-no", "OrderNo"},
+		{"2fa_code", "X2faCode"},
+		{"_", "X"},
+	} {
+		if goName := toGoName(c.name); goName != c.goName {
+			t.Errorf("go name of %s is %s, expect %s", c.name, goName, c.goName)
+		}
+	}
+}
+
+func TestGetGoType(t *testing.T) {
+	for _, c := range []struct {
+		col      *Column
+		nullable string
+		goType   string
+		pkg      string
+	}{
+		{&Column{Type: "TINYINT"}, NullableStyleSQL, "int8", ""},
+		{&Column{Type: "BIGINT", Unsigned: true}, NullableStyleSQL, "uint64", ""},
+		{&Column{Type: "BIGINT", Unsigned: true, Nullable: true}, NullableStyleSQL, "*uint64", ""},
+		{&Column{Type: "INT", Unsigned: true, Nullable: true}, NullableStyleSQL, "sql.NullInt64", "database/sql"},
+		{&Column{Type: "DECIMAL"}, NullableStyleSQL, "string", ""},
+		{&Column{Type: "DOUBLE", Nullable: true}, NullableStyleSQL, "sql.NullFloat64", "database/sql"},
+		{&Column{Type: "DATETIME"}, NullableStyleSQL, "time.Time", "time"},
+		{&Column{Type: "DATETIME", Nullable: true}, NullableStyleSQL, "sql.NullTime", "database/sql"},
+		{&Column{Type: "DATETIME", Nullable: true}, NullableStylePointer, "*time.Time", "time"},
+		{&Column{Type: "VARCHAR", Nullable: true}, NullableStylePointer, "*string", ""},
+		{&Column{Type: "BLOB", Nullable: true}, NullableStyleSQL, "[]byte", ""},
+	} {
+		goType, pkg := getGoType(c.col, c.nullable)
+		if goType != c.goType || pkg != c.pkg {
+			t.Errorf("go type of %+v with %s nullable is %s from %q, expect %s from %q", c.col, c.nullable, goType,
+				pkg, c.goType, c.pkg)
+		}
+	}
+}
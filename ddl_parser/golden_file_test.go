@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Regenerate the golden files of the test with `go test -run <test> -update`
+var update = flag.Bool("update", false, "update the golden files under testdata")
+
+// Leading comment of the case giving the options in JSON format, e.g. `-- options: {"dialect": "tidb"}`
+const goldenOptionTag = "-- options:"
+
+// checkGoldenFile: compare the output with the golden file, or overwrite the golden file with `-update`
+func checkGoldenFile(t *testing.T, goldenFile string, actual []byte) {
+	if *update {
+		if err := ioutil.WriteFile(goldenFile, actual, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	expected, err := ioutil.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatalf("%s, run with -update to create it", err.Error())
+	}
+	if !bytes.Equal(expected, actual) {
+		t.Errorf("output mismatch %s, run with -update if the change is expected\nexpected:\n%s\nactual:\n%s",
+			goldenFile, expected, actual)
+	}
+}
+
+// getGoldenFiles: `.sql` cases under the directory in name order
+func getGoldenFiles(t testing.TB, dir string) []string {
+	var cases []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && filepath.Ext(path) == ".sql" {
+			cases = append(cases, path)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(cases)
+	return cases
+}
+
+// getGoldenOptions: options given in the leading comment of the case, empty if not given
+func getGoldenOptions(sql string) string {
+	if !strings.HasPrefix(sql, goldenOptionTag) {
+		return ""
+	}
+	end := strings.Index(sql, "\n")
+	if end < 0 {
+		end = len(sql)
+	}
+	return sql[len(goldenOptionTag):end]
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/sidai/parser/dml"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const goldenDir = "testdata/golden"
+
+// goldenResult: the part of the result covered by golden files
+type goldenResult struct {
+	SQL       string           `json:"sql"`
+	Position  Position         `json:"position"`
+	OldTable  string           `json:"old_table"`
+	NewTable  string           `json:"new_table"`
+	DDLType   []string         `json:"ddl_list"`
+	DMLType   string           `json:"dml_type,omitempty"` // Only given if the statement is routed to the DML analyzer
+	DMLChunk  *dml.ChunkPlan   `json:"dml_chunk,omitempty"`
+	Findings  []*ReturnFinding `json:"findings"`
+	Baselined []*ReturnFinding `json:"baselined,omitempty"`
+
+	RequiresApproval []*Approval `json:"requires_approval,omitempty"`
+	Risk             *Risk       `json:"risk,omitempty"` // Only covered when any factor contributes
+
+	Estimate *DurationEstimate `json:"estimate,omitempty"`
+}
+
+// TestGolden: parse each `.sql` case and compare the findings with the `.json` golden file next to it
+// Parse options can be given in the leading comment of the case, e.g. `-- options: {"dialect": "tidb"}`
+func TestGolden(t *testing.T) {
+	for _, sqlFile := range getGoldenCases(t) {
+		sqlFile := sqlFile
+		name := strings.TrimSuffix(strings.TrimPrefix(filepath.ToSlash(sqlFile), goldenDir+"/"), ".sql")
+		t.Run(name, func(t *testing.T) {
+			checkGoldenFile(t, strings.TrimSuffix(sqlFile, ".sql")+".json", runGoldenCase(t, sqlFile))
+		})
+	}
+}
+
+// TestGoldenRuleCoverage: every reported rule must be covered by at least one golden file
+func TestGoldenRuleCoverage(t *testing.T) {
+	covered := make(map[string]struct{})
+	for _, sqlFile := range getGoldenCases(t) {
+		var results []*goldenResult
+		if err := json.Unmarshal(runGoldenCase(t, sqlFile), &results); err != nil {
+			t.Fatal(err)
+		}
+		for _, result := range results {
+			for _, finding := range result.Findings {
+				covered[finding.Rule] = struct{}{}
+			}
+		}
+	}
+
+	var missing []string
+	for err, rule := range DDLErrorRuleMap {
+		if _, ok := covered[rule]; !ok && DDLErrorMsgTypeMap[err] != DDLMsgTypeIgnore {
+			missing = append(missing, rule)
+		}
+	}
+	sort.Strings(missing)
+	if len(missing) != 0 {
+		t.Errorf("rules without golden case: %s", strings.Join(missing, ", "))
+	}
+}
+
+func getGoldenCases(t testing.TB) []string {
+	return getGoldenFiles(t, goldenDir)
+}
+
+func getGoldenRisk(risk *Risk) *Risk {
+	if risk == nil || len(risk.Factors) == 0 {
+		return nil
+	}
+	return risk
+}
+
+func runGoldenCase(t *testing.T, sqlFile string) []byte {
+	content, err := ioutil.ReadFile(sqlFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sql := string(content)
+	options := getGoldenOptions(sql)
+
+	var results []*goldenResult
+	for _, r := range parseWithOptions(sql, options) {
+		result := r.toReturnResult()
+		var dmlType string
+		var dmlChunk *dml.ChunkPlan
+		if result.DML != nil {
+			dmlType, dmlChunk = result.DML.DMLType, result.DML.Chunk
+		}
+		results = append(results, &goldenResult{
+			SQL:       result.SQL,
+			Position:  result.Position,
+			OldTable:  result.OldTable,
+			NewTable:  result.NewTable,
+			DDLType:   result.DDLType,
+			DMLType:   dmlType,
+			DMLChunk:  dmlChunk,
+			Findings:  result.Findings,
+			Baselined: result.Baselined,
+
+			RequiresApproval: result.RequiresApproval,
+			Risk:             getGoldenRisk(result.Risk),
+
+			Estimate: result.Estimate,
+		})
+	}
+
+	actual, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(actual, '\n')
+}
@@ -10,6 +10,9 @@ var (
 		ast.TableOptionCharset: checkTableOptCharsetDef,
 		ast.TableOptionCollate: checkTableOptCollateDef,
 		ast.TableOptionEngine:  checkTableOptEngineDef,
+
+		ast.TableOptionShardRowID:     checkTableOptTiDBRowIDDef,
+		ast.TableOptionPreSplitRegion: checkTableOptTiDBRowIDDef,
 	}
 	reqTableOptNotFoundErr = map[ast.TableOptionType]error{
 		ast.TableOptionCharset: NoCharsetErr,
@@ -40,7 +40,7 @@ func checkPartTypeDef(r *ParseResult, part *ast.PartitionOptions) {
 		}
 	}
 	if containsHash {
-		r.AddError(PartWithHashErr.Accept(part))
+		r.AddError(PartWithHashErr.Accept(restoreClause(part)))
 	}
 }
 
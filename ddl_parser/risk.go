@@ -0,0 +1,339 @@
+package main
+
+import (
+	"fmt"
+	"github.com/pingcap/parser/ast"
+	"strings"
+)
+
+// Risk Level Constant
+const (
+	RiskLevelLow      = "low"
+	RiskLevelMedium   = "medium"
+	RiskLevelHigh     = "high"
+	RiskLevelCritical = "critical"
+)
+
+// Risk Factor Constant
+const (
+	RiskFactorDropTable           = "drop_table"
+	RiskFactorDropPartition       = "drop_partition"
+	RiskFactorDropColumn          = "drop_column"
+	RiskFactorLossyTypeChange     = "lossy_type_change"
+	RiskFactorTypeChange          = "type_change"
+	RiskFactorRemovePartitioning  = "remove_partitioning"
+	RiskFactorTableRebuild        = "table_rebuild"
+	RiskFactorRename              = "rename"
+	RiskFactorDropIndex           = "drop_index"
+	RiskFactorLockingAlterOnLarge = "locking_alter_on_large_table"
+)
+
+// Score of each factor, the score of the statement and batch is the sum of the factors up to `maxRiskScore`
+var riskFactorScoreMap = map[string]int{
+	RiskFactorDropTable:           80,
+	RiskFactorDropPartition:       60,
+	RiskFactorDropColumn:          40,
+	RiskFactorLossyTypeChange:     40,
+	RiskFactorTypeChange:          20,
+	RiskFactorRemovePartitioning:  30,
+	RiskFactorTableRebuild:        20,
+	RiskFactorRename:              20,
+	RiskFactorDropIndex:           10,
+	RiskFactorLockingAlterOnLarge: 40,
+}
+
+const maxRiskScore = 100
+
+// Risk: score from 0 to 100 with the level derived from it, and the factors contributing to the score
+type Risk struct {
+	Score   int           `json:"score"`
+	Level   string        `json:"level"`
+	Factors []*RiskFactor `json:"factors"`
+
+	table      string // Table altered by the statement
+	buildIndex bool   // Index is built without table rebuild
+}
+
+type RiskFactor struct {
+	Factor    string `json:"factor"`
+	Score     int    `json:"score"`
+	Detail    string `json:"detail"`
+	Statement int    `json:"statement,omitempty"` // Index of the statement from 1, only set in the risk of batch
+}
+
+func NewRisk() *Risk {
+	return &Risk{Level: RiskLevelLow, Factors: []*RiskFactor{}}
+}
+
+func (r *Risk) addFactor(factor string, detail string) {
+	for _, added := range r.Factors {
+		if added.Factor == factor && added.Detail == detail {
+			return
+		}
+	}
+	r.Factors = append(r.Factors, &RiskFactor{Factor: factor, Score: riskFactorScoreMap[factor], Detail: detail})
+	r.setScore(r.Score + riskFactorScoreMap[factor])
+}
+
+// addRebuild: the table is rebuilt at most once by the statement however many specs require it
+func (r *Risk) addRebuild(detail string) {
+	if !r.hasFactor(RiskFactorTableRebuild) {
+		r.addFactor(RiskFactorTableRebuild, detail)
+	}
+}
+
+func (r *Risk) hasFactor(factor string) bool {
+	for _, added := range r.Factors {
+		if added.Factor == factor {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Risk) setScore(score int) {
+	if score > maxRiskScore {
+		score = maxRiskScore
+	}
+	r.Score = score
+	switch {
+	case score >= 80:
+		r.Level = RiskLevelCritical
+	case score >= 50:
+		r.Level = RiskLevelHigh
+	case score >= 20:
+		r.Level = RiskLevelMedium
+	default:
+		r.Level = RiskLevelLow
+	}
+}
+
+// getBatchRisk: sum up the risk of the statements, factors are tagged with the statement index
+func getBatchRisk(results []*ParseResult) *Risk {
+	batch := NewRisk()
+	score := 0
+	for i, r := range results {
+		if r.Risk == nil {
+			continue
+		}
+		score += r.Risk.Score
+		for _, factor := range r.Risk.Factors {
+			batchFactor := *factor
+			batchFactor.Statement = i + 1
+			batch.Factors = append(batch.Factors, &batchFactor)
+		}
+	}
+	batch.setScore(score)
+	return batch
+}
+
+// assessRisks: assess the risk of the statements in order, the schema is evolved by each statement if given
+// Statement not applicable to the schema is reported, e.g. altering the column not deployed
+func assessRisks(results []*ParseResult, catalog *Catalog, sizes *TableSizes) {
+	for _, r := range results {
+		r.Risk = assessRisk(r.Stmt, catalog, sizes)
+		if catalog != nil && r.Stmt != nil {
+			if err := catalog.Apply(r.Stmt); err != nil {
+				r.AddError(SchemaMismatchErr.Accept(err.Error()))
+			}
+		}
+	}
+}
+
+// assessRisk: the old column type is known only if the schema is given, otherwise type change is assumed possibly lossy
+func assessRisk(stmt ast.StmtNode, catalog *Catalog, sizes *TableSizes) *Risk {
+	risk := NewRisk()
+	switch s := stmt.(type) {
+	case *ast.DropTableStmt:
+		if s.IsView {
+			break
+		}
+		for _, table := range s.Tables {
+			risk.addFactor(RiskFactorDropTable, fmt.Sprintf("drop table `%s`", getTableName(table)))
+		}
+	case *ast.TruncateTableStmt:
+		risk.addFactor(RiskFactorDropTable, fmt.Sprintf("truncate table `%s`", getTableName(s.Table)))
+	case *ast.RenameTableStmt:
+		for _, t2t := range s.TableToTables {
+			risk.addFactor(RiskFactorRename, fmt.Sprintf("rename table `%s` to `%s`", getTableName(t2t.OldTable),
+				getTableName(t2t.NewTable)))
+		}
+	case *ast.DropIndexStmt:
+		risk.addFactor(RiskFactorDropIndex, fmt.Sprintf("drop index `%s`", s.IndexName))
+	case *ast.CreateIndexStmt:
+		tableName := getTableName(s.Table)
+		risk.table, risk.buildIndex = tableName, true
+		if sizes.IsLarge(tableName) {
+			risk.addFactor(RiskFactorLockingAlterOnLarge, fmt.Sprintf("create index `%s` on large table `%s`",
+				s.IndexName, tableName))
+		}
+	case *ast.AlterTableStmt:
+		assessAlterTableRisk(risk, s, catalog, sizes)
+	}
+	return risk
+}
+
+func assessAlterTableRisk(risk *Risk, stmt *ast.AlterTableStmt, catalog *Catalog, sizes *TableSizes) {
+	tableName := getTableName(stmt.Table)
+	risk.table = tableName
+	var table *Table
+	if catalog != nil {
+		table = catalog.GetTable(tableName)
+	}
+
+	locking := false
+	for _, spec := range stmt.Specs {
+		switch spec.Tp {
+		case ast.AlterTableDropColumn:
+			risk.addFactor(RiskFactorDropColumn, fmt.Sprintf("drop column `%s`", spec.OldColumnName.Name.String()))
+			risk.addRebuild("drop column")
+		case ast.AlterTableModifyColumn, ast.AlterTableChangeColumn:
+			if len(spec.NewColumns) == 0 {
+				break
+			}
+			newCol := buildColumnModel(spec.NewColumns[0])
+			oldName := newCol.Name
+			if spec.Tp == ast.AlterTableChangeColumn {
+				oldName = spec.OldColumnName.Name.String()
+				if !strings.EqualFold(oldName, newCol.Name) {
+					risk.addFactor(RiskFactorRename, fmt.Sprintf("rename column `%s` to `%s`", oldName, newCol.Name))
+				}
+			}
+			locking = true
+			var oldCol *Column
+			if table != nil {
+				oldCol = table.GetColumn(oldName)
+			}
+			if oldCol == nil {
+				risk.addFactor(RiskFactorTypeChange, fmt.Sprintf("column `%s` changed to `%s` from unknown type, "+
+					"the change may be lossy", oldName, newCol.ColumnType))
+				risk.addRebuild("modify column")
+				break
+			}
+			if isLossyTypeChange(oldCol, newCol) {
+				risk.addFactor(RiskFactorLossyTypeChange, fmt.Sprintf("column `%s` changed from `%s` to `%s`", oldName,
+					oldCol.ColumnType, newCol.ColumnType))
+			}
+			if oldCol.ColumnType != newCol.ColumnType || oldCol.Nullable != newCol.Nullable {
+				risk.addRebuild("modify column")
+			}
+		case ast.AlterTableRenameColumn:
+			risk.addFactor(RiskFactorRename, fmt.Sprintf("rename column `%s` to `%s`", spec.OldColumnName.Name.String(),
+				spec.NewColumnName.Name.String()))
+		case ast.AlterTableRenameTable:
+			risk.addFactor(RiskFactorRename, fmt.Sprintf("rename table `%s` to `%s`", tableName,
+				getTableName(spec.NewTable)))
+		case ast.AlterTableAddColumns:
+			if pos := spec.Position; pos != nil && pos.Tp != ast.ColumnPositionNone {
+				risk.addRebuild("add column with position")
+			}
+		case ast.AlterTableAddConstraint:
+			locking = true
+			if spec.Constraint != nil && spec.Constraint.Tp == ast.ConstraintPrimaryKey {
+				risk.addRebuild("add primary key")
+			} else if spec.Constraint != nil && spec.Constraint.Tp != ast.ConstraintCheck {
+				risk.buildIndex = true
+			}
+		case ast.AlterTableDropPrimaryKey:
+			risk.addRebuild("drop primary key")
+		case ast.AlterTableDropIndex:
+			risk.addFactor(RiskFactorDropIndex, fmt.Sprintf("drop index `%s`", spec.Name))
+		case ast.AlterTableOption:
+			for _, option := range spec.Options {
+				if option.Tp == ast.TableOptionEngine || option.Tp == ast.TableOptionRowFormat ||
+					(option.Tp == ast.TableOptionCharset && option.UintValue == ast.TableOptionCharsetWithConvertTo) {
+					risk.addRebuild("change table option")
+				}
+			}
+		case ast.AlterTableForce:
+			risk.addRebuild("force rebuild")
+		case ast.AlterTableDropPartition:
+			for _, partName := range spec.PartitionNames {
+				risk.addFactor(RiskFactorDropPartition, fmt.Sprintf("drop partition `%s`", partName.String()))
+			}
+		case ast.AlterTableTruncatePartition:
+			for _, partName := range spec.PartitionNames {
+				risk.addFactor(RiskFactorDropPartition, fmt.Sprintf("truncate partition `%s`", partName.String()))
+			}
+		case ast.AlterTableRemovePartitioning:
+			risk.addFactor(RiskFactorRemovePartitioning, "remove partitioning")
+			risk.addRebuild("remove partitioning")
+		case ast.AlterTablePartition, ast.AlterTableCoalescePartitions, ast.AlterTableReorganizePartition,
+			ast.AlterTableRebuildPartition:
+			risk.addRebuild("repartition")
+		}
+	}
+
+	if (locking || risk.hasFactor(RiskFactorTableRebuild)) && sizes.IsLarge(tableName) {
+		risk.addFactor(RiskFactorLockingAlterOnLarge, fmt.Sprintf("locking alter on large table `%s`", tableName))
+	}
+}
+
+// Storage capacity of the string types, used to compare the types across the family
+var stringTypeCapacityMap = map[string]int{
+	"TINYTEXT":   1<<8 - 1,
+	"TEXT":       1<<16 - 1,
+	"MEDIUMTEXT": 1<<24 - 1,
+	"LONGTEXT":   1<<32 - 1,
+	"TINYBLOB":   1<<8 - 1,
+	"BLOB":       1<<16 - 1,
+	"MEDIUMBLOB": 1<<24 - 1,
+	"LONGBLOB":   1<<32 - 1,
+}
+
+// Rank of the integer types by range
+var intTypeRankMap = map[string]int{
+	"TINYINT":   1,
+	"SMALLINT":  2,
+	"MEDIUMINT": 3,
+	"INT":       4,
+	"BIGINT":    5,
+}
+
+// isLossyTypeChange: change is lossy if the new type cannot hold all values of the old type
+// Only widening within the integer, decimal and string families is considered lossless
+func isLossyTypeChange(oldCol *Column, newCol *Column) bool {
+	if oldCol.ColumnType == newCol.ColumnType {
+		return false
+	}
+
+	oldRank, oldIsInt := intTypeRankMap[oldCol.Type]
+	newRank, newIsInt := intTypeRankMap[newCol.Type]
+	if oldIsInt && newIsInt {
+		if oldCol.Unsigned == newCol.Unsigned {
+			return newRank < oldRank
+		}
+		// Signed widened to hold the unsigned range, unsigned never holds negative
+		return newCol.Unsigned || newRank <= oldRank
+	}
+
+	oldCap, oldIsString := getStringCapacity(oldCol)
+	newCap, newIsString := getStringCapacity(newCol)
+	if oldIsString && newIsString {
+		return newCap < oldCap || (newCol.Charset != "" && !strings.EqualFold(oldCol.Charset, newCol.Charset))
+	}
+
+	if oldCol.Type == "DECIMAL" && newCol.Type == "DECIMAL" {
+		return getIntValue(newCol.Scale) < getIntValue(oldCol.Scale) ||
+			getIntValue(newCol.Precision)-getIntValue(newCol.Scale) < getIntValue(oldCol.Precision)-getIntValue(oldCol.Scale)
+	}
+	return true
+}
+
+func getStringCapacity(col *Column) (int, bool) {
+	if capacity, ok := stringTypeCapacityMap[col.Type]; ok {
+		return capacity, true
+	}
+	switch col.Type {
+	case "CHAR", "VARCHAR", "BINARY", "VARBINARY":
+		return getIntValue(col.Length), true
+	}
+	return 0, false
+}
+
+func getIntValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"testing"
+)
+
+// TestBatchRisk: the batch score is the sum of the statements up to the max score with factors tagged by statement
+func TestBatchRisk(t *testing.T) {
+	results := parseWithOptions("ALTER TABLE example ADD COLUMN code VARCHAR(64) NOT NULL DEFAULT '' FIRST;\n"+
+		"ALTER TABLE example DROP INDEX index_code;\n", "")
+	risk := getBatchRisk(results)
+	if risk.Score != 30 || risk.Level != RiskLevelMedium || len(risk.Factors) != 2 || risk.Factors[1].Statement != 2 {
+		t.Fatalf("unexpected batch risk %+v", risk)
+	}
+
+	results = parseWithOptions("DROP TABLE example;\nALTER TABLE example_log DROP COLUMN code;\n", "")
+	risk = getBatchRisk(results)
+	if risk.Score != maxRiskScore || risk.Level != RiskLevelCritical {
+		t.Fatalf("unexpected batch risk %+v", risk)
+	}
+}
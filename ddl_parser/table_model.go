@@ -0,0 +1,372 @@
+package main
+
+import (
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/parser/types"
+	driver "github.com/pingcap/tidb/types/parser_driver"
+	"strings"
+)
+
+// Table: structured model of the table defined by `CREATE TABLE`
+type Table struct {
+	Name      string         `json:"name"`
+	Columns   []*Column      `json:"columns"`
+	Indexes   []*Index       `json:"indexes"`
+	Partition *Partition     `json:"partition"`
+	Options   []*TableOption `json:"options"`
+}
+
+type Column struct {
+	Name          string   `json:"name"`
+	Type          string   `json:"type"`
+	ColumnType    string   `json:"column_type"`
+	Length        *int     `json:"length,omitempty"`
+	Precision     *int     `json:"precision,omitempty"`
+	Scale         *int     `json:"scale,omitempty"`
+	Unsigned      bool     `json:"unsigned"`
+	Nullable      bool     `json:"nullable"`
+	Default       *string  `json:"default"`
+	OnUpdate      *string  `json:"on_update"`
+	AutoIncrement bool     `json:"auto_increment"`
+	Generated     *string  `json:"generated,omitempty"`
+	Elems         []string `json:"elems,omitempty"`
+	Comment       string   `json:"comment"`
+	Charset       string   `json:"charset"`
+	Collation     string   `json:"collation"`
+}
+
+type Index struct {
+	Name    string         `json:"name"`
+	Type    string         `json:"type"`
+	Columns []*IndexColumn `json:"columns"`
+	Comment string         `json:"comment"`
+}
+
+type IndexColumn struct {
+	Name   string `json:"name,omitempty"`
+	Expr   string `json:"expr,omitempty"`
+	Length *int   `json:"length,omitempty"` // Prefix length
+}
+
+type Partition struct {
+	Type        string                 `json:"type"`
+	Linear      bool                   `json:"linear"`
+	Expr        string                 `json:"expr,omitempty"`
+	Columns     []string               `json:"columns,omitempty"`
+	Num         uint64                 `json:"num,omitempty"`
+	Definitions []*PartitionDefinition `json:"definitions,omitempty"`
+	Sub         *Partition             `json:"sub_partition,omitempty"`
+}
+
+type PartitionDefinition struct {
+	Name    string `json:"name"`
+	Values  string `json:"values,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
+
+type TableOption struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Index Type Constant
+const (
+	IndexTypePrimary  = "PRIMARY"
+	IndexTypeUnique   = "UNIQUE"
+	IndexTypeIndex    = "INDEX"
+	IndexTypeFullText = "FULLTEXT"
+	IndexTypeSpatial  = "SPATIAL"
+	IndexTypeForeign  = "FOREIGN"
+	IndexTypeCheck    = "CHECK"
+)
+
+var (
+	conTypeIndexTypeMap = map[ast.ConstraintType]string{
+		ast.ConstraintPrimaryKey:   IndexTypePrimary,
+		ast.ConstraintKey:          IndexTypeIndex,
+		ast.ConstraintIndex:        IndexTypeIndex,
+		ast.ConstraintUniq:         IndexTypeUnique,
+		ast.ConstraintUniqKey:      IndexTypeUnique,
+		ast.ConstraintUniqIndex:    IndexTypeUnique,
+		ast.ConstraintForeignKey:   IndexTypeForeign,
+		ast.ConstraintSpatial:      IndexTypeSpatial,
+		ast.ConstraintSpatialKey:   IndexTypeSpatial,
+		ast.ConstraintSpatialIndex: IndexTypeSpatial,
+		ast.ConstraintFulltext:     IndexTypeFullText,
+		ast.ConstraintCheck:        IndexTypeCheck,
+	}
+
+	tableOptionNameMap = map[ast.TableOptionType]string{
+		ast.TableOptionEngine:           "engine",
+		ast.TableOptionCharset:          "charset",
+		ast.TableOptionCollate:          "collate",
+		ast.TableOptionAutoIncrement:    "auto_increment",
+		ast.TableOptionComment:          "comment",
+		ast.TableOptionAvgRowLength:     "avg_row_length",
+		ast.TableOptionCheckSum:         "checksum",
+		ast.TableOptionCompression:      "compression",
+		ast.TableOptionConnection:       "connection",
+		ast.TableOptionPassword:         "password",
+		ast.TableOptionKeyBlockSize:     "key_block_size",
+		ast.TableOptionMaxRows:          "max_rows",
+		ast.TableOptionMinRows:          "min_rows",
+		ast.TableOptionDelayKeyWrite:    "delay_key_write",
+		ast.TableOptionRowFormat:        "row_format",
+		ast.TableOptionStatsPersistent:  "stats_persistent",
+		ast.TableOptionStatsAutoRecalc:  "stats_auto_recalc",
+		ast.TableOptionShardRowID:       "shard_row_id_bits",
+		ast.TableOptionPreSplitRegion:   "pre_split_regions",
+		ast.TableOptionPackKeys:         "pack_keys",
+		ast.TableOptionTablespace:       "tablespace",
+		ast.TableOptionStatsSamplePages: "stats_sample_pages",
+		ast.TableOptionEncryption:       "encryption",
+	}
+)
+
+// buildTableModel: build the structured table model from the `CREATE TABLE` statement
+func buildTableModel(stmt *ast.CreateTableStmt) *Table {
+	table := &Table{
+		Name:      getTableName(stmt.Table),
+		Columns:   getAllColumns(stmt.Cols),
+		Partition: buildPartitionModel(stmt.Partition),
+		Options:   buildTableOptionModels(stmt.Options),
+	}
+
+	for _, col := range stmt.Cols {
+		table.Indexes = append(table.Indexes, buildInlineIndexModels(col)...)
+	}
+	for _, con := range stmt.Constraints {
+		table.Indexes = append(table.Indexes, buildIndexModel(con))
+	}
+
+	// Columns of `PRIMARY KEY` are implicitly `NOT NULL`
+	for _, index := range table.Indexes {
+		if index.Type == IndexTypePrimary {
+			for _, indexCol := range index.Columns {
+				if col := table.GetColumn(indexCol.Name); col != nil {
+					col.Nullable = false
+				}
+			}
+		}
+	}
+	return table
+}
+
+// GetColumn: return the column with the given name case insensitively, nil if not found
+func (t *Table) GetColumn(colName string) *Column {
+	for _, col := range t.Columns {
+		if strings.EqualFold(col.Name, colName) {
+			return col
+		}
+	}
+	return nil
+}
+
+// GetOption: return the value of the last table option with the given name, empty if not found
+func (t *Table) GetOption(name string) string {
+	value := ""
+	for _, option := range t.Options {
+		if option.Name == name {
+			value = option.Value
+		}
+	}
+	return value
+}
+
+// buildColumnModel: build the structured column model from the column definition
+func buildColumnModel(col *ast.ColumnDef) *Column {
+	colInfo := col.Tp
+	column := &Column{
+		Name:       col.Name.Name.String(),
+		Type:       strings.ToUpper(types.TypeToStr(colInfo.Tp, colInfo.Charset)),
+		ColumnType: colInfo.InfoSchemaStr(),
+		Unsigned:   mysql.HasUnsignedFlag(colInfo.Flag),
+		Nullable:   true,
+		Elems:      colInfo.Elems,
+		Charset:    colInfo.Charset,
+		Collation:  colInfo.Collate,
+	}
+
+	switch colInfo.Tp {
+	case mysql.TypeNewDecimal, mysql.TypeFloat, mysql.TypeDouble:
+		column.Precision = getSpecifiedLength(colInfo.Flen)
+		column.Scale = getSpecifiedLength(colInfo.Decimal)
+	case mysql.TypeDatetime, mysql.TypeTimestamp, mysql.TypeDuration:
+		column.Precision = getSpecifiedLength(colInfo.Decimal)
+	default:
+		column.Length = getSpecifiedLength(colInfo.Flen)
+	}
+
+	for _, option := range col.Options {
+		switch option.Tp {
+		case ast.ColumnOptionNotNull, ast.ColumnOptionPrimaryKey:
+			column.Nullable = false
+		case ast.ColumnOptionNull:
+			column.Nullable = true
+		case ast.ColumnOptionDefaultValue:
+			column.Default = getRestoredPtr(option.Expr)
+		case ast.ColumnOptionOnUpdate:
+			column.OnUpdate = getRestoredPtr(option.Expr)
+		case ast.ColumnOptionAutoIncrement:
+			column.AutoIncrement = true
+		case ast.ColumnOptionGenerated:
+			column.Generated = getRestoredPtr(option.Expr)
+		case ast.ColumnOptionComment:
+			if expr, ok := option.Expr.(*driver.ValueExpr); ok {
+				column.Comment = expr.GetString()
+			}
+		case ast.ColumnOptionCollate:
+			column.Collation = option.StrValue
+		}
+	}
+	return column
+}
+
+// buildIndexModel: build the structured index model from the constraint, `PRIMARY KEY` is named `PRIMARY`
+func buildIndexModel(con *ast.Constraint) *Index {
+	index := &Index{
+		Name: con.Name,
+		Type: conTypeIndexTypeMap[con.Tp],
+	}
+	if index.Type == IndexTypePrimary {
+		index.Name = IndexTypePrimary
+	}
+	if con.Option != nil {
+		index.Comment = con.Option.Comment
+	}
+
+	for _, key := range con.Keys {
+		indexCol := &IndexColumn{}
+		if key.Length > 0 {
+			indexCol.Length = &key.Length
+		}
+		if key.Column != nil {
+			indexCol.Name = key.Column.Name.String()
+		} else if key.Expr != nil {
+			indexCol.Expr = restoreClause(key.Expr)
+		}
+		index.Columns = append(index.Columns, indexCol)
+	}
+	return index
+}
+
+// buildInlineIndexModels: build the index models declared inline in the column definition
+func buildInlineIndexModels(col *ast.ColumnDef) []*Index {
+	var indexes []*Index
+	colName := col.Name.Name.String()
+	for _, option := range col.Options {
+		if option.Tp == ast.ColumnOptionPrimaryKey {
+			indexes = append(indexes, &Index{Name: IndexTypePrimary, Type: IndexTypePrimary,
+				Columns: []*IndexColumn{{Name: colName}}})
+		} else if option.Tp == ast.ColumnOptionUniqKey {
+			indexes = append(indexes, &Index{Name: colName, Type: IndexTypeUnique,
+				Columns: []*IndexColumn{{Name: colName}}})
+		}
+	}
+	return indexes
+}
+
+// buildPartitionModel: build the structured partition model, return nil if the table is not partitioned
+func buildPartitionModel(part *ast.PartitionOptions) *Partition {
+	if part == nil {
+		return nil
+	}
+	partition := buildPartitionMethodModel(&part.PartitionMethod)
+	if part.Sub != nil {
+		partition.Sub = buildPartitionMethodModel(part.Sub)
+	}
+
+	for _, def := range part.Definitions {
+		partition.Definitions = append(partition.Definitions, buildPartitionDefinitionModel(def))
+	}
+	return partition
+}
+
+func buildPartitionDefinitionModel(def *ast.PartitionDefinition) *PartitionDefinition {
+	partDef := &PartitionDefinition{Name: def.Name.String()}
+	switch clause := def.Clause.(type) {
+	case *ast.PartitionDefinitionClauseLessThan:
+		partDef.Values = "LESS THAN (" + restoreExprs(clause.Exprs) + ")"
+	case *ast.PartitionDefinitionClauseIn:
+		// Tuple is only parenthesized if it has more than one value, e.g. `IN ('a','b')` or `IN ((1,'a'),(2,'b'))`
+		var values []string
+		for _, value := range clause.Values {
+			if len(value) == 1 {
+				values = append(values, restoreExprs(value))
+			} else {
+				values = append(values, "("+restoreExprs(value)+")")
+			}
+		}
+		partDef.Values = "IN (" + strings.Join(values, ",") + ")"
+	}
+	partDef.Comment, _ = def.Comment()
+	return partDef
+}
+
+func buildPartitionMethodModel(method *ast.PartitionMethod) *Partition {
+	partition := &Partition{
+		Type:   method.Tp.String(),
+		Linear: method.Linear,
+		Num:    method.Num,
+	}
+	if method.Expr != nil {
+		partition.Expr = restoreClause(method.Expr)
+	}
+	for _, col := range method.ColumnNames {
+		partition.Columns = append(partition.Columns, col.Name.String())
+	}
+	return partition
+}
+
+// buildTableOptionModels: build the table options in declaration order
+func buildTableOptionModels(options []*ast.TableOption) []*TableOption {
+	var tableOptions []*TableOption
+	for _, option := range options {
+		restored := restoreTableOption(option)
+		name, value := restored, ""
+		if tokens := strings.SplitN(restored, " = ", 2); len(tokens) == 2 {
+			name, value = tokens[0], tokens[1]
+		}
+		if optName, ok := tableOptionNameMap[option.Tp]; ok {
+			name = optName
+		}
+		if option.StrValue != "" {
+			value = option.StrValue
+		}
+		tableOptions = append(tableOptions, &TableOption{Name: name, Value: value})
+	}
+	return tableOptions
+}
+
+func getTableName(table *ast.TableName) string {
+	tableName := table.Name.String()
+	if schemaName := table.Schema.String(); schemaName != "" {
+		tableName = schemaName + DBNameSeparator + tableName
+	}
+	return tableName
+}
+
+// Return nil if the length is unspecified
+func getSpecifiedLength(length int) *int {
+	if length == types.UnspecifiedLength {
+		return nil
+	}
+	return &length
+}
+
+func getRestoredPtr(node ast.Node) *string {
+	if node == nil {
+		return nil
+	}
+	restored := restoreClause(node)
+	return &restored
+}
+
+func restoreExprs(exprs []ast.ExprNode) string {
+	var restored []string
+	for _, expr := range exprs {
+		restored = append(restored, restoreClause(expr))
+	}
+	return strings.Join(restored, ",")
+}
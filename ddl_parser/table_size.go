@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default thresholds and throughputs of `TableSizeConfig`
+const (
+	defaultLargeTableBytes       int64 = 10 << 30
+	defaultLargeTableRows        int64 = 10000000
+	defaultRebuildBytesPerSecond int64 = 50 << 20
+	defaultIndexBytesPerSecond   int64 = 100 << 20
+)
+
+// TableSizeConfig: thresholds marking the table large and throughputs used to estimate the duration
+type TableSizeConfig struct {
+	LargeTableBytes       int64 `json:"large_table_bytes"`        // Data plus index length, default to 10 GiB
+	LargeTableRows        int64 `json:"large_table_rows"`         // Default to 10 million rows
+	RebuildBytesPerSecond int64 `json:"rebuild_bytes_per_second"` // Throughput copying the table, default to 50 MiB/s
+	IndexBytesPerSecond   int64 `json:"index_bytes_per_second"`   // Throughput scanning the table to build index, default to 100 MiB/s
+}
+
+func (c *TableSizeConfig) setDefaults() {
+	if c.LargeTableBytes <= 0 {
+		c.LargeTableBytes = defaultLargeTableBytes
+	}
+	if c.LargeTableRows <= 0 {
+		c.LargeTableRows = defaultLargeTableRows
+	}
+	if c.RebuildBytesPerSecond <= 0 {
+		c.RebuildBytesPerSecond = defaultRebuildBytesPerSecond
+	}
+	if c.IndexBytesPerSecond <= 0 {
+		c.IndexBytesPerSecond = defaultIndexBytesPerSecond
+	}
+}
+
+// TableSizes: snapshot of `information_schema.TABLES` loaded from the file given in `table_sizes` option
+// The file is either CSV with header or JSON of the rows, with or without the `tables` wrapper
+// e.g. `SELECT TABLE_SCHEMA, TABLE_NAME, TABLE_ROWS, DATA_LENGTH, INDEX_LENGTH FROM information_schema.TABLES`
+type TableSizes struct {
+	Tables []*TableSize `json:"tables"`
+
+	config *TableSizeConfig
+}
+
+// TableSize: the table is large if marked `Large` or above any threshold of `TableSizeConfig`
+// Fields are matched with the columns of `information_schema.TABLES` case insensitively
+type TableSize struct {
+	Schema      string `json:"table_schema"`
+	Table       string `json:"table_name"`
+	Rows        int64  `json:"table_rows"`
+	DataLength  int64  `json:"data_length"`
+	IndexLength int64  `json:"index_length"`
+	Large       bool   `json:"large"`
+}
+
+// DurationEstimate: estimated duration of the operation copying or scanning the table
+type DurationEstimate struct {
+	Table     string  `json:"table"`
+	Operation string  `json:"operation"` // `rebuild` or `build index`
+	Bytes     int64   `json:"bytes"`
+	Seconds   float64 `json:"seconds"`
+}
+
+// Estimated Operation Constant
+const (
+	EstimateOperationRebuild    = "rebuild"
+	EstimateOperationBuildIndex = "build index"
+)
+
+func LoadTableSizes(file string, config *TableSizeConfig) (*TableSizes, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := &TableSizes{}
+	if strings.ToLower(filepath.Ext(file)) == ".csv" {
+		sizes.Tables, err = parseTableSizesCSV(content)
+	} else if trimmed := bytes.TrimSpace(content); len(trimmed) != 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(content, &sizes.Tables)
+	} else {
+		err = json.Unmarshal(content, sizes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, size := range sizes.Tables {
+		if size.Table == "" {
+			return nil, fmt.Errorf("table size #%d must have `table_name`", i+1)
+		}
+	}
+
+	sizes.config = &TableSizeConfig{}
+	if config != nil {
+		*sizes.config = *config
+	}
+	sizes.config.setDefaults()
+	return sizes, nil
+}
+
+// parseTableSizesCSV: columns are located by the header, `TABLE_NAME` is required and the others are optional
+func parseTableSizesCSV(content []byte) ([]*TableSize, error) {
+	records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["table_name"]; !ok {
+		return nil, fmt.Errorf("table size csv must have header `TABLE_NAME`")
+	}
+
+	var sizes []*TableSize
+	for line, record := range records[1:] {
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		getInt := func(name string) (int64, error) {
+			value := get(name)
+			if value == "" || strings.EqualFold(value, "NULL") {
+				return 0, nil
+			}
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("line %d has invalid `%s` `%s`", line+2, strings.ToUpper(name), value)
+			}
+			return n, nil
+		}
+
+		size := &TableSize{Schema: get("table_schema"), Table: get("table_name")}
+		if size.Rows, err = getInt("table_rows"); err != nil {
+			return nil, err
+		}
+		if size.DataLength, err = getInt("data_length"); err != nil {
+			return nil, err
+		}
+		if size.IndexLength, err = getInt("index_length"); err != nil {
+			return nil, err
+		}
+		size.Large, _ = strconv.ParseBool(get("large"))
+		sizes = append(sizes, size)
+	}
+	return sizes, nil
+}
+
+// GetTableSize: return the size of the table case insensitively, nil if not found or no sizes given
+// Table name qualified with database name only matches the size of the same schema
+func (s *TableSizes) GetTableSize(tableName string) *TableSize {
+	if s == nil {
+		return nil
+	}
+	schemaName := ""
+	if i := strings.Index(tableName, DBNameSeparator); i >= 0 {
+		schemaName, tableName = tableName[:i], tableName[i+len(DBNameSeparator):]
+	}
+	for _, size := range s.Tables {
+		if strings.EqualFold(size.Table, tableName) && (schemaName == "" || strings.EqualFold(size.Schema, schemaName)) {
+			return size
+		}
+	}
+	return nil
+}
+
+func (s *TableSizes) IsLarge(tableName string) bool {
+	size := s.GetTableSize(tableName)
+	return size != nil && (size.Large || size.DataLength+size.IndexLength >= s.config.LargeTableBytes ||
+		size.Rows >= s.config.LargeTableRows)
+}
+
+// Estimate: rebuild copies the data and indexes, build index scans the data, nil if the size is unknown
+func (s *TableSizes) Estimate(tableName string, operation string) *DurationEstimate {
+	size := s.GetTableSize(tableName)
+	if size == nil {
+		return nil
+	}
+
+	estimate := &DurationEstimate{Table: tableName, Operation: operation, Bytes: size.DataLength}
+	throughput := s.config.IndexBytesPerSecond
+	if operation == EstimateOperationRebuild {
+		estimate.Bytes += size.IndexLength
+		throughput = s.config.RebuildBytesPerSecond
+	}
+	estimate.Seconds = float64(estimate.Bytes) / float64(throughput)
+	return estimate
+}
+
+func (e *DurationEstimate) Duration() time.Duration {
+	return time.Duration(e.Seconds * float64(time.Second)).Round(time.Second)
+}
+
+// formatBytes: format the bytes in binary units, e.g. `2.0 TiB`
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// checkTableSizes: estimate the duration of the statement rebuilding or indexing the table
+// Rebuilding or indexing large table is reported as error, the other findings of the statement keep their own level
+func checkTableSizes(results []*ParseResult, sizes *TableSizes) {
+	for _, r := range results {
+		if r.Risk == nil || r.Risk.table == "" {
+			continue
+		}
+		operation := EstimateOperationBuildIndex
+		if r.Risk.hasFactor(RiskFactorTableRebuild) {
+			operation = EstimateOperationRebuild
+		} else if !r.Risk.buildIndex {
+			continue
+		}
+		r.Estimate = sizes.Estimate(r.Risk.table, operation)
+		if r.Estimate == nil || !sizes.IsLarge(r.Risk.table) {
+			continue
+		}
+
+		size := sizes.GetTableSize(r.Risk.table)
+		if operation == EstimateOperationBuildIndex {
+			r.AddError(LargeTableIndexErr.Accept(r.Risk.table, size.Rows, formatBytes(size.DataLength),
+				r.Estimate.Duration()))
+			continue
+		}
+		r.AddError(LargeTableRebuildErr.Accept(r.Risk.table, size.Rows, formatBytes(size.DataLength+size.IndexLength),
+			r.Estimate.Duration()))
+	}
+}
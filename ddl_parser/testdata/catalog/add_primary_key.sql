@@ -0,0 +1,3 @@
+CREATE TABLE example (id BIGINT UNSIGNED, code VARCHAR(64), name VARCHAR(64));
+ALTER TABLE example ADD PRIMARY KEY (id, code);
+ALTER TABLE example ADD CONSTRAINT uk_name UNIQUE KEY (name);
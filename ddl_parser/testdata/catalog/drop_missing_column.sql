@@ -0,0 +1,3 @@
+CREATE TABLE example (id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT, PRIMARY KEY (id));
+ALTER TABLE example DROP COLUMN name;
+CREATE TABLE example_after (id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT, PRIMARY KEY (id));
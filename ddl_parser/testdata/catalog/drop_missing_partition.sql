@@ -0,0 +1,9 @@
+CREATE TABLE example_log (
+  id BIGINT UNSIGNED NOT NULL,
+  created_at DATETIME NOT NULL,
+  PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE COLUMNS(created_at) (
+  PARTITION p2019 VALUES LESS THAN ('2020-01-01'),
+  PARTITION p2020 VALUES LESS THAN ('2021-01-01')
+);
+ALTER TABLE example_log DROP PARTITION p2018;
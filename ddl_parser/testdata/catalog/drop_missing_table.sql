@@ -0,0 +1,2 @@
+CREATE TABLE example (id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT, PRIMARY KEY (id));
+DROP TABLE example, example_order;
@@ -0,0 +1,13 @@
+CREATE TABLE example_log (
+  id BIGINT UNSIGNED NOT NULL,
+  created_at DATETIME NOT NULL,
+  PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE COLUMNS(created_at) (
+  PARTITION p2019 VALUES LESS THAN ('2020-01-01'),
+  PARTITION p2020 VALUES LESS THAN ('2021-01-01')
+);
+ALTER TABLE example_log ADD PARTITION (PARTITION p2021 VALUES LESS THAN ('2022-01-01'));
+ALTER TABLE example_log DROP PARTITION p2019;
+CREATE TABLE example_hash (id BIGINT UNSIGNED NOT NULL, PRIMARY KEY (id)) PARTITION BY HASH(id) PARTITIONS 4;
+ALTER TABLE example_hash COALESCE PARTITION 2;
+ALTER TABLE example_hash REMOVE PARTITIONING;
@@ -0,0 +1,3 @@
+CREATE TABLE example (id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT, PRIMARY KEY (id));
+CREATE TABLE example_order (id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT, PRIMARY KEY (id));
+RENAME TABLE example_order TO example;
@@ -0,0 +1,29 @@
+CREATE TABLE example (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  name VARCHAR(64) NOT NULL DEFAULT '',
+  remark VARCHAR(255) NULL,
+  PRIMARY KEY (id),
+  KEY index_name (name)
+) COMMENT = 'example';
+CREATE TABLE example_order (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  example_id BIGINT UNSIGNED NOT NULL,
+  PRIMARY KEY (id)
+);
+CREATE TABLE example_tmp LIKE example;
+INSERT INTO example (id, name) VALUES (1, 'ignored');
+ALTER TABLE example
+  ADD COLUMN code VARCHAR(32) NOT NULL DEFAULT '' AFTER id,
+  MODIFY COLUMN name VARCHAR(128) NOT NULL DEFAULT '',
+  DROP COLUMN remark,
+  RENAME INDEX index_name TO index_example_name,
+  COMMENT = 'example table';
+ALTER TABLE example CHANGE COLUMN code example_code VARCHAR(32) NOT NULL DEFAULT '';
+ALTER TABLE example ALTER COLUMN name SET DEFAULT 'unknown';
+CREATE UNIQUE INDEX uk_example_code ON example (example_code);
+CREATE INDEX index_example_id ON example_order (example_id);
+DROP INDEX index_example_id ON example_order;
+RENAME TABLE example_order TO example_purchase;
+ALTER TABLE example_purchase RENAME TO example_checkout;
+DROP TABLE example_tmp;
+DROP TABLE IF EXISTS example_missing;
@@ -0,0 +1,14 @@
+-- options: {"format": "html"}
+CREATE TABLE example (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  name VARCHAR(64) NOT NULL DEFAULT '' COMMENT '<b>name</b>',
+  PRIMARY KEY (id)
+) COMMENT = 'example & more';
+CREATE TABLE example_list (
+  id BIGINT UNSIGNED NOT NULL,
+  region VARCHAR(8) NOT NULL,
+  PRIMARY KEY (id, region)
+) PARTITION BY LIST COLUMNS(region) (
+  PARTITION p_east VALUES IN ('east'),
+  PARTITION p_west VALUES IN ('west')
+);
@@ -0,0 +1,23 @@
+-- options: {"title": "Example Schema"}
+CREATE TABLE example (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  name VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'name | alias',
+  remark TEXT NULL,
+  full_name VARCHAR(128) AS (CONCAT(name, '-')) VIRTUAL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY uk_name (name),
+  KEY index_created_at (created_at) COMMENT 'sort by creation'
+) COMMENT = 'example
+table';
+CREATE TABLE example_key (id BIGINT UNSIGNED NOT NULL, PRIMARY KEY (id)) PARTITION BY KEY(id) PARTITIONS 4;
+CREATE TABLE example_log (
+  id BIGINT UNSIGNED NOT NULL,
+  created_at DATETIME NOT NULL,
+  PRIMARY KEY (id, created_at)
+) PARTITION BY RANGE COLUMNS(created_at) (
+  PARTITION p2020 VALUES LESS THAN ('2021-01-01'),
+  PARTITION pmax VALUES LESS THAN (MAXVALUE)
+);
+CREATE TABLE example_hash (id BIGINT UNSIGNED NOT NULL, PRIMARY KEY (id)) PARTITION BY LINEAR HASH(id) PARTITIONS 8;
@@ -0,0 +1,29 @@
+-- options: {"format": "mermaid"}
+CREATE TABLE user (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  email VARCHAR(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (id),
+  UNIQUE KEY unique_email (email)
+);
+CREATE TABLE tag (
+  name VARCHAR(64) NOT NULL DEFAULT '',
+  PRIMARY KEY (name)
+);
+CREATE TABLE `order` (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  user_id BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  referrer_user_id BIGINT UNSIGNED NULL,
+  User_ID2 BIGINT UNSIGNED NULL,
+  category_id BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  tag_id BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  remark VARCHAR(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (id),
+  KEY index_user_id (user_id, category_id),
+  KEY index_remark (remark(16))
+);
+CREATE TABLE order_item (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  Order_ID BIGINT UNSIGNED NULL,
+  PRIMARY KEY (id)
+);
+UPDATE user SET email = '' WHERE id = 1;
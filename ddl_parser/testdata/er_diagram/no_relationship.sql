@@ -0,0 +1,10 @@
+CREATE TABLE user (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  PRIMARY KEY (id)
+);
+CREATE TABLE audit_log (
+  log_id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  member_id BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  user_uid VARCHAR(64) NOT NULL DEFAULT '',
+  PRIMARY KEY (log_id)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE example ADD COLUMN code VARCHAR(32) NOT NULL DEFAULT '';
+CREATE TABLE example (id INT NOT NULL, PRIMARY KEY (id));
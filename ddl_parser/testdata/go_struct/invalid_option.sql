@@ -0,0 +1,2 @@
+-- options: {"nullable": "optional"}
+CREATE TABLE example (id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT, PRIMARY KEY (id));
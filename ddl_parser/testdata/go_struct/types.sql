@@ -0,0 +1,18 @@
+CREATE TABLE example_order (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  user_id BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'buyer',
+  api_url VARCHAR(255) NOT NULL DEFAULT '',
+  status TINYINT NOT NULL DEFAULT 0,
+  quantity INT UNSIGNED NULL,
+  price DECIMAL(10,2) NOT NULL DEFAULT 0,
+  ratio DOUBLE NULL,
+  remark TEXT NULL,
+  payload BLOB NULL,
+  paid_at DATETIME NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY index_user_id (user_id),
+  KEY index_created_at (created_at),
+  KEY index_updated_at (updated_at)
+) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='order of the example';
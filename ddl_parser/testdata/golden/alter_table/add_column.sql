@@ -0,0 +1,4 @@
+ALTER TABLE example ADD COLUMN name VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE example ADD COLUMN code VARCHAR(64) NOT NULL;
+ALTER TABLE example ADD COLUMN `Status` ENUM('active') NOT NULL DEFAULT 'active' FIRST;
+ALTER TABLE example ADD COLUMN remark VARCHAR(64) NULL DEFAULT NULL AFTER name;
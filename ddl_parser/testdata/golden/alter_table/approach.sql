@@ -0,0 +1,2 @@
+ALTER TABLE example ADD COLUMN name VARCHAR(64) NOT NULL DEFAULT '', ALGORITHM=INPLACE, LOCK=NONE;
+ALTER TABLE example ADD COLUMN code VARCHAR(64) NOT NULL DEFAULT '', ALGORITHM=INSTANT;
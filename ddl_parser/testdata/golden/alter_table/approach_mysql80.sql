@@ -0,0 +1,2 @@
+-- options: {"dialect": "mysql", "version": "8.0.12"}
+ALTER TABLE example ADD COLUMN code VARCHAR(64) NOT NULL DEFAULT '', ALGORITHM=INSTANT;
@@ -0,0 +1,7 @@
+ALTER TABLE example ADD KEY index_name (name);
+ALTER TABLE example ADD UNIQUE KEY code (code);
+ALTER TABLE example DROP PRIMARY KEY;
+ALTER TABLE example DROP INDEX index_created_at;
+ALTER TABLE example RENAME INDEX index_updated_at TO index_update;
+ALTER TABLE example ADD CONSTRAINT fk_parent FOREIGN KEY (parent_id) REFERENCES parent (id);
+ALTER TABLE example DROP FOREIGN KEY fk_parent;
@@ -0,0 +1 @@
+ALTER TABLE example_history DROP INDEX index_updated_at;
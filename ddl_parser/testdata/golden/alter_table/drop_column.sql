@@ -0,0 +1,4 @@
+ALTER TABLE example DROP COLUMN name;
+ALTER TABLE example DROP COLUMN id;
+ALTER TABLE example DROP COLUMN created_at;
+ALTER TABLE example DROP COLUMN updated_at;
@@ -0,0 +1,5 @@
+ALTER TABLE example MODIFY COLUMN name VARCHAR(128) NOT NULL DEFAULT '';
+ALTER TABLE example CHANGE COLUMN name full_name VARCHAR(128) NOT NULL DEFAULT '';
+ALTER TABLE example CHANGE COLUMN id uid BIGINT UNSIGNED NOT NULL AUTO_INCREMENT;
+ALTER TABLE example RENAME COLUMN created_at TO create_time;
+ALTER TABLE example ALTER COLUMN name SET DEFAULT 'name';
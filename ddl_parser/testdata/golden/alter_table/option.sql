@@ -0,0 +1,3 @@
+ALTER TABLE example COLLATE=utf8mb4_general_ci;
+ALTER TABLE example ENGINE=MyISAM;
+ALTER TABLE example COMMENT='example';
@@ -0,0 +1,2 @@
+ALTER TABLE example ORDER BY name;
+ALTER TABLE example DISABLE KEYS;
@@ -0,0 +1,5 @@
+ALTER TABLE example ADD PARTITION (PARTITION p2 VALUES LESS THAN (MAXVALUE));
+ALTER TABLE example DROP PARTITION p0;
+ALTER TABLE example REMOVE PARTITIONING;
+ALTER TABLE example TRUNCATE PARTITION p1;
+ALTER TABLE example PARTITION BY HASH(id) PARTITIONS 4;
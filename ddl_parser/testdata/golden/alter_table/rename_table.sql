@@ -0,0 +1,2 @@
+ALTER TABLE example RENAME TO example_new;
+ALTER TABLE example RENAME TO `Example-New`;
@@ -0,0 +1,4 @@
+-- options: {"approval_roles": {"ColDroppedErr": "DBA", "PartRemovedErr": "", "ColTimeStampTypeErr": "DE", "ForeignIDBigIntUnsigned": "DE"}, "custom_rules": [{"id": "ForeignIDBigIntUnsigned", "scope": "column", "expr": "!column.name.endsWith('_id') || (column.type == 'BIGINT' && column.unsigned)"}]}
+ALTER TABLE example DROP COLUMN code, ADD COLUMN shop_id INT NOT NULL DEFAULT 0;
+ALTER TABLE example ADD COLUMN logged_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE example REMOVE PARTITIONING;
@@ -0,0 +1,3 @@
+ALTER TABLE example DROP COLUMN code, DROP COLUMN remark;
+ALTER TABLE example DROP PARTITION p2019;
+ALTER TABLE example REMOVE PARTITIONING;
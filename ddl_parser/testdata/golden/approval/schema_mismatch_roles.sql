@@ -0,0 +1,3 @@
+-- options: {"approval_roles": {"SchemaMismatchErr": "DBA"}, "schema_dir": "testdata/schema"}
+ALTER TABLE example DROP COLUMN missing_column;
+ALTER TABLE missing_table ADD COLUMN code VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+-- options: {"approval_roles": {"LargeTableRebuildErr": "DBA", "LargeTableIndexErr": ""}, "table_sizes": "testdata/golden/table_size/tables.csv"}
+ALTER TABLE example_order ENGINE=InnoDB;
+ALTER TABLE example_order ADD INDEX index_example_id (example_id);
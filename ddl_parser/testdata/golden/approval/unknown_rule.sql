@@ -0,0 +1,2 @@
+-- options: {"approval_roles": {"ColumnDroppedErr": "DE"}}
+ALTER TABLE example DROP COLUMN code;
@@ -0,0 +1,3 @@
+-- options: {"baseline": {"file": "testdata/golden/baseline/accepted.baseline"}}
+ALTER TABLE example ADD COLUMN code VARCHAR(64) NOT NULL AFTER name;
+ALTER TABLE example ADD COLUMN remark VARCHAR(64) NOT NULL AFTER code;
@@ -0,0 +1,2 @@
+-- options: {"baseline": {"file": "testdata/golden/baseline/missing/accepted.baseline", "update": true}}
+ALTER TABLE example ADD COLUMN code VARCHAR(64) NOT NULL AFTER name;
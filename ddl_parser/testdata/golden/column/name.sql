@@ -0,0 +1,11 @@
+CREATE TABLE example (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  `first-name` VARCHAR(64) NOT NULL DEFAULT '',
+  `Last_Name` VARCHAR(64) NOT NULL DEFAULT '',
+  `key` VARCHAR(64) NOT NULL DEFAULT '',
+  PRIMARY KEY (id),
+  KEY index_created_at (created_at),
+  KEY index_updated_at (updated_at)
+) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
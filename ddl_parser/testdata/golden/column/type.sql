@@ -0,0 +1,16 @@
+CREATE TABLE example (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  code VARCHAR(64) NOT NULL DEFAULT '' UNIQUE,
+  expired_at TIMESTAMP NULL DEFAULT NULL,
+  amount INT(11) NOT NULL DEFAULT 0,
+  enabled TINYINT(1) NOT NULL DEFAULT 0,
+  content TEXT NOT NULL DEFAULT 'content',
+  extra JSON NULL DEFAULT NULL,
+  status ENUM('active', 'inactive') NOT NULL DEFAULT 'active',
+  remark VARCHAR(64) NOT NULL DEFAULT NULL,
+  PRIMARY KEY (id),
+  KEY index_created_at (created_at),
+  KEY index_updated_at (updated_at)
+) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,10 @@
+CREATE TABLE example (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  amount INT NOT NULL DEFAULT 0,
+  PRIMARY KEY (id),
+  KEY index_created_at (created_at),
+  KEY index_updated_at (updated_at),
+  CONSTRAINT chk_amount CHECK (amount >= 0)
+) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
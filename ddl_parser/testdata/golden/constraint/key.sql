@@ -0,0 +1,12 @@
+CREATE TABLE example (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  name VARCHAR(64) NOT NULL DEFAULT '',
+  PRIMARY KEY (id),
+  KEY index_created_at (created_at),
+  KEY index_updated_at (updated_at),
+  KEY name (name),
+  KEY index_name_created_at (created_at, name),
+  KEY index_unknown (unknown)
+) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1 @@
+CREATE TABLE example LIKE example_old;
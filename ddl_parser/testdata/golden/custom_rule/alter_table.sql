@@ -0,0 +1,3 @@
+-- options: {"custom_rules": [{"id": "TableCommentRequired", "expr": "table.comment != ''"}, {"id": "ForeignIDBigIntUnsigned", "scope": "column", "expr": "!column.name.endsWith('_id') || (column.type == 'BIGINT' && column.unsigned)", "message": "column `{column}` in `{table}` referring to id must use `BIGINT UNSIGNED`"}, {"id": "VarcharMaxLength", "scope": "column", "expr": "column.length <= 255", "message": "column `{column}` must not be longer than 255"}]}
+ALTER TABLE example ADD COLUMN shop_id BIGINT NOT NULL DEFAULT 0, ADD COLUMN remark VARCHAR(1024) NOT NULL DEFAULT '';
+ALTER TABLE example ADD COLUMN deleted_at DATETIME NULL;
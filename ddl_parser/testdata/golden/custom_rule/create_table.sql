@@ -0,0 +1,19 @@
+-- options: {"custom_rules": [{"id": "TableCommentRequired", "expr": "table.comment != ''", "message": "table `{table}` must have comment"}, {"id": "ForeignIDBigIntUnsigned", "scope": "column", "expr": "!column.name.endsWith('_id') || (column.type == 'BIGINT' && column.unsigned)", "severity": "warning", "message": "column `{column}` in `{table}` referring to id must use `BIGINT UNSIGNED`"}, {"id": "TooManyIndexes", "expr": "size(table.indexes) <= 3", "message": "table `{table}` must not have more than 3 indexes"}, {"id": "IndexMaxColumns", "scope": "index", "expr": "size(index.columns) <= 2", "severity": "warning", "message": "index `{index}` must not have more than 2 columns"}]}
+CREATE TABLE example (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  user_id INT NOT NULL DEFAULT 0,
+  order_id BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  name VARCHAR(64) NOT NULL DEFAULT '',
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY index_created_at (created_at),
+  KEY index_user_id (user_id),
+  KEY index_order_id (order_id),
+  KEY index_user_id_order_id_name (user_id, order_id, name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+CREATE TABLE example_commented (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  KEY index_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='example';
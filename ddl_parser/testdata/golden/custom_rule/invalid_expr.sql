@@ -0,0 +1,2 @@
+-- options: {"custom_rules": [{"id": "TableNameLength", "expr": "size(table.name)"}]}
+CREATE TABLE example (id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT, PRIMARY KEY (id));
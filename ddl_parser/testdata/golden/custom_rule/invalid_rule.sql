@@ -0,0 +1,2 @@
+-- options: {"custom_rules": [{"id": "ColIDNotFoundErr", "expr": "true"}]}
+CREATE TABLE example (id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT, PRIMARY KEY (id));
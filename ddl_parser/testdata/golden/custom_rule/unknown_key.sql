@@ -0,0 +1,2 @@
+-- options: {"custom_rules": [{"id": "VarcharMaxLength", "scope": "column", "expr": "column.lenght <= 255"}]}
+ALTER TABLE example ADD COLUMN remark VARCHAR(1024) NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- options: {"schema_dir": "testdata/schema", "dry_run": true, "approval_roles": {"DryRunErr": "DBA"}}
+ALTER TABLE example ADD INDEX index_created_at (user_id);
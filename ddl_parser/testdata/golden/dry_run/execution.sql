@@ -0,0 +1,8 @@
+-- options: {"schema_dir": "testdata/schema", "dry_run": true}
+ALTER TABLE example ADD COLUMN name VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'name';
+ALTER TABLE example ADD COLUMN expired_at DATETIME NOT NULL DEFAULT 'yesterday' COMMENT 'expired at';
+ALTER TABLE example ADD COLUMN title VARCHAR(1024) NOT NULL DEFAULT '' COMMENT 'title';
+ALTER TABLE example ADD INDEX index_title (title);
+ALTER TABLE example ADD INDEX index_created_at (user_id);
+CREATE TABLE example_archive (id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT, created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP, updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP, PRIMARY KEY (id, created_at), KEY index_created_at (created_at), KEY index_updated_at (updated_at)) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci PARTITION BY RANGE (created_at) (PARTITION p0 VALUES LESS THAN (2020));
+ALTER TABLE example_order ADD COLUMN remark VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'remark', ADD INDEX index_remark (remark);
@@ -0,0 +1,2 @@
+-- options: {"schema_dir": "testdata/schema_unsupported", "dry_run": true}
+ALTER TABLE example DROP INDEX index_code;
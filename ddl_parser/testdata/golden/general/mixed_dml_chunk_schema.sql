@@ -0,0 +1,22 @@
+-- options: {"schema_dir": "testdata/schema", "dml": {"chunk": {"primary_keys": {"example": "name"}}}}
+CREATE TABLE example_log (
+  log_id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (log_id),
+  KEY index_created_at (created_at),
+  KEY index_updated_at (updated_at)
+) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+DELETE FROM example_log WHERE created_at < '2020-01-01';
+CREATE TABLE example_code (
+  code VARCHAR(64) NOT NULL DEFAULT '',
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (code),
+  KEY index_created_at (created_at),
+  KEY index_updated_at (updated_at)
+) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+DELETE FROM example_code WHERE created_at < '2020-01-01';
+DELETE FROM example WHERE created_at < '2020-01-01';
+DELETE FROM example_order WHERE created_at < '2020-01-01';
+DELETE FROM example_other WHERE created_at < '2020-01-01';
@@ -0,0 +1,2 @@
+-- options: {"dml": {"unique_keys": {"example": ["uk_code"], "EXAMPLE": ["uk_name"]}}}
+REPLACE INTO example (id, code) VALUES (1, 'a');
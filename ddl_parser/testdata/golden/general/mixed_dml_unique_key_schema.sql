@@ -0,0 +1,17 @@
+-- options: {"schema_dir": "testdata/schema", "dml": {"unique_keys": {"example_order": ["uk_given"]}}}
+CREATE TABLE example_code (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+  code VARCHAR(64) NOT NULL DEFAULT '',
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY uk_code (code),
+  KEY index_created_at (created_at),
+  KEY index_updated_at (updated_at)
+) DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+REPLACE INTO example_code (id, code) VALUES (1, 'a');
+INSERT IGNORE INTO example (id, name) VALUES (1, 'a');
+ALTER TABLE example ADD UNIQUE INDEX uk_name (name);
+INSERT IGNORE INTO example (id, name) VALUES (1, 'a');
+ALTER TABLE example_order ADD UNIQUE INDEX uk_example_id (example_id);
+INSERT IGNORE INTO example_order (id, example_id) VALUES (1, 1);
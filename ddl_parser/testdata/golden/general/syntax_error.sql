@@ -0,0 +1 @@
+CREATE TABLE example (id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,;
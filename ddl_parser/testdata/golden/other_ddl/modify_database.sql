@@ -0,0 +1,3 @@
+CREATE DATABASE example_db;
+ALTER DATABASE example_db CHARACTER SET utf8mb4;
+DROP DATABASE example_db;
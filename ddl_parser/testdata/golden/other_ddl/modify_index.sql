@@ -0,0 +1,2 @@
+CREATE INDEX index_name ON example (name);
+DROP INDEX index_name ON example;
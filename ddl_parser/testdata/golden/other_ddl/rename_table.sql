@@ -0,0 +1 @@
+RENAME TABLE example TO example_new;
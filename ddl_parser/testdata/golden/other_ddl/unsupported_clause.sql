@@ -0,0 +1 @@
+CREATE VIEW example_view AS SELECT id FROM example;
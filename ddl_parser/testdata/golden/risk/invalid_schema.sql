@@ -0,0 +1,2 @@
+-- options: {"schema_dir": "testdata/missing"}
+ALTER TABLE example MODIFY COLUMN name VARCHAR(32) NOT NULL DEFAULT '';
@@ -0,0 +1,11 @@
+DROP TABLE example_log;
+TRUNCATE TABLE example_log;
+RENAME TABLE example TO example_old;
+ALTER TABLE example DROP COLUMN remark, DROP COLUMN code, DROP INDEX index_name;
+ALTER TABLE example MODIFY COLUMN name VARCHAR(32) NOT NULL DEFAULT '';
+ALTER TABLE example CHANGE COLUMN name title VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE example ADD COLUMN code VARCHAR(64) NOT NULL DEFAULT '' AFTER name;
+ALTER TABLE example ADD COLUMN code VARCHAR(64) NOT NULL DEFAULT '', COMMENT 'example';
+ALTER TABLE example ENGINE=InnoDB;
+ALTER TABLE example DROP PARTITION p2019, p2020;
+ALTER TABLE example REMOVE PARTITIONING;
@@ -0,0 +1,12 @@
+-- options: {"schema_dir": "testdata/schema", "table_sizes": "testdata/golden/risk/large.sizes"}
+ALTER TABLE example MODIFY COLUMN name VARCHAR(32) NOT NULL DEFAULT '';
+ALTER TABLE example MODIFY COLUMN name VARCHAR(128) NOT NULL DEFAULT '';
+ALTER TABLE example MODIFY COLUMN amount DECIMAL(10,2) NOT NULL DEFAULT 0;
+ALTER TABLE example MODIFY COLUMN remark VARCHAR(255);
+ALTER TABLE example MODIFY COLUMN name VARCHAR(128) NOT NULL DEFAULT '' COMMENT 'name';
+ALTER TABLE example_order MODIFY COLUMN status SMALLINT NOT NULL DEFAULT 0;
+ALTER TABLE example_order MODIFY COLUMN status TINYINT UNSIGNED NOT NULL DEFAULT 0;
+ALTER TABLE example_order ADD INDEX index_example_id (example_id);
+ALTER TABLE example_order ADD COLUMN remark TEXT;
+ALTER TABLE example DROP COLUMN code;
+ALTER TABLE example_log ADD COLUMN code VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+-- options: {"table_sizes": "testdata/golden/table_size/tables.csv"}
+ALTER TABLE example_order ADD COLUMN code VARCHAR(64) NOT NULL DEFAULT '' AFTER status;
+ALTER TABLE example_order ADD INDEX index_example_id (example_id);
+CREATE INDEX index_status ON shop.example_order (status);
+ALTER TABLE example ADD COLUMN code VARCHAR(64) NOT NULL DEFAULT '' AFTER name;
+ALTER TABLE other.example_order ENGINE=InnoDB;
+ALTER TABLE example_log ENGINE=InnoDB;
@@ -0,0 +1,3 @@
+-- options: {"table_sizes": "testdata/golden/table_size/tables.sizes", "table_size_config": {"large_table_rows": 10000000, "rebuild_bytes_per_second": 104857600}}
+ALTER TABLE example_order ENGINE=InnoDB;
+ALTER TABLE example ENGINE=InnoDB;
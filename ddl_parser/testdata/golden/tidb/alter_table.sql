@@ -0,0 +1,6 @@
+-- options: {"dialect": "tidb", "version": "4.0"}
+ALTER TABLE example ADD COLUMN seq BIGINT NOT NULL DEFAULT 0 AUTO_RANDOM;
+ALTER TABLE example ADD COLUMN location GEOMETRY NOT NULL;
+ALTER TABLE example ADD FULLTEXT KEY index_content (content);
+ALTER TABLE example COALESCE PARTITION 2;
+ALTER TABLE example SHARD_ROW_ID_BITS=4 PRE_SPLIT_REGIONS=8;
@@ -0,0 +1,2 @@
+-- options: {"waivers": "testdata/golden/waiver/invalid_waiver.waivers"}
+ALTER TABLE archive_order DROP PARTITION p2019;
@@ -0,0 +1,4 @@
+-- options: {"waivers": "testdata/golden/waiver/waivers.waivers"}
+ALTER TABLE archive_order DROP PARTITION p2019;
+ALTER TABLE legacy_log ADD COLUMN logged_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE legacy_event ADD COLUMN logged_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
@@ -0,0 +1,5 @@
+CREATE TABLE example (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    PRIMARY KEY (id)
+);
+ALTER TABLE example ADD COLUMN code VARCHAR(32) NOT NULL DEFAULT '', ADD INDEX index_code (code);
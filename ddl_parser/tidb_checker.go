@@ -0,0 +1,244 @@
+package main
+
+import (
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/format"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/parser/types"
+	"strings"
+)
+
+var (
+	// Partition types supported by TiDB, `RANGE COLUMNS` is only supported with single column
+	tidbSupportedPartType = map[model.PartitionType]struct{}{
+		model.PartitionTypeRange: {},
+		model.PartitionTypeHash:  {},
+	}
+
+	// Partition modifications not supported by TiDB
+	tidbUnsupportedAlterPart = map[ast.AlterTableType]string{
+		ast.AlterTableCoalescePartitions:         "`COALESCE PARTITION`",
+		ast.AlterTablePartition:                  "`PARTITION BY` in `ALTER TABLE`",
+		ast.AlterTableRemovePartitioning:         "`REMOVE PARTITIONING`",
+		ast.AlterTableRebuildPartition:           "`REBUILD PARTITION`",
+		ast.AlterTableReorganizePartition:        "`REORGANIZE PARTITION`",
+		ast.AlterTableExchangePartition:          "`EXCHANGE PARTITION`",
+		ast.AlterTableOptimizePartition:          "`OPTIMIZE PARTITION`",
+		ast.AlterTableRepairPartition:            "`REPAIR PARTITION`",
+		ast.AlterTableImportPartitionTablespace:  "`IMPORT PARTITION TABLESPACE`",
+		ast.AlterTableDiscardPartitionTablespace: "`DISCARD PARTITION TABLESPACE`",
+	}
+)
+
+// TiDB Table Check, only applied when the target is TiDB:
+// Rule 1: `AUTO_INCREMENT` on clustered `BIGINT` primary key creates write hotspot, use `AUTO_RANDOM` instead (Warning)
+//      2: `AUTO_RANDOM` column must be the clustered `BIGINT` primary key without `AUTO_INCREMENT` or `DEFAULT`
+//      3: Table without clustered primary key should set `SHARD_ROW_ID_BITS` to scatter the implicit row id (Warning)
+//      4: `SHARD_ROW_ID_BITS` cannot be used on table with clustered primary key
+//      5: `PRE_SPLIT_REGIONS` requires `SHARD_ROW_ID_BITS` or `AUTO_RANDOM` and must not exceed `SHARD_ROW_ID_BITS`
+//      6: Partition type must be supported by TiDB
+//      7: Spatial column, spatial index and full text index are not supported by TiDB
+func checkTiDBTableDef(r *ParseResult, cols []*ast.ColumnDef, cons []*ast.Constraint, opts []*ast.TableOption,
+	part *ast.PartitionOptions) {
+	if r.Target.Dialect != DialectTiDB {
+		return
+	}
+
+	clusteredCol := getTiDBClusteredColumn(r.Target, cols, cons)
+	for _, col := range cols {
+		checkTiDBColumnDef(r, col, col == clusteredCol) // Rule 1, 2, 7
+	}
+	for _, con := range cons {
+		checkTiDBConstraintDef(r, con) // Rule 7
+	}
+	checkTiDBRowIDDef(r, opts, clusteredCol)        // Rule 3, 4, 5
+	checkTiDBPartitionDef(r, part)                  // Rule 6
+}
+
+// TiDB Alter Table Check, only applied when the target is TiDB:
+// Rule 1: Cannot add `AUTO_RANDOM` column
+//      2: Spatial column, spatial index and full text index are not supported by TiDB
+//      3: Partition type and partition modification must be supported by TiDB
+//      4: `PRE_SPLIT_REGIONS` must not exceed `SHARD_ROW_ID_BITS`
+func checkTiDBAlterSpecDef(r *ParseResult, spec *ast.AlterTableSpec) {
+	if r.Target.Dialect != DialectTiDB {
+		return
+	}
+
+	for _, col := range spec.NewColumns {
+		if hasColumnOption(col, ast.ColumnOptionAutoRandom) { // Rule 1
+			r.AddError(TiDBAutoRandomAddedErr.Accept(col.Name.String()))
+		}
+		checkTiDBSpatialColumnDef(r, col) // Rule 2
+	}
+	if spec.Constraint != nil {
+		checkTiDBConstraintDef(r, spec.Constraint) // Rule 2
+	}
+	if feature, ok := tidbUnsupportedAlterPart[spec.Tp]; ok { // Rule 3
+		r.AddError(UnsupportedFeatureErr.Accept(feature, r.Target, restoreClause(spec)))
+	}
+	if len(spec.Options) != 0 {
+		checkTiDBPreSplitDef(r, spec.Options, false) // Rule 4
+	}
+}
+
+func checkTiDBColumnDef(r *ParseResult, col *ast.ColumnDef, clustered bool) {
+	colName := col.Name.Name.String()
+	autoRandom := hasColumnOption(col, ast.ColumnOptionAutoRandom)
+	autoInc := hasColumnOption(col, ast.ColumnOptionAutoIncrement)
+	isBigInt := col.Tp != nil && col.Tp.Tp == mysql.TypeLonglong
+
+	if autoRandom {
+		if !clustered || !isBigInt {
+			r.AddError(TiDBAutoRandomNotPKErr.Accept(colName))
+		}
+		if autoInc {
+			r.AddError(TiDBAutoRandomConflictErr.Accept(colName, "AUTO_INCREMENT"))
+		}
+		if hasColumnOption(col, ast.ColumnOptionDefaultValue) {
+			r.AddError(TiDBAutoRandomConflictErr.Accept(colName, "DEFAULT"))
+		}
+	} else if autoInc && clustered && isBigInt && r.Target.Supports(featureAutoRandom) {
+		r.AddError(TiDBAutoIncHotspotErr.Accept(colName))
+	}
+
+	checkTiDBSpatialColumnDef(r, col)
+}
+
+func checkTiDBSpatialColumnDef(r *ParseResult, col *ast.ColumnDef) {
+	if col.Tp != nil && col.Tp.Tp == mysql.TypeGeometry {
+		r.AddError(UnsupportedFeatureErr.Accept("spatial column", r.Target, restoreClause(col)))
+	}
+}
+
+func checkTiDBConstraintDef(r *ParseResult, con *ast.Constraint) {
+	switch conTypeStringMap[con.Tp] {
+	case spatialKeyPrefix:
+		r.AddError(UnsupportedFeatureErr.Accept("`SPATIAL INDEX`", r.Target, restoreClause(con)))
+	case fullTextPrefix:
+		r.AddError(UnsupportedFeatureErr.Accept("`FULLTEXT INDEX`", r.Target, restoreClause(con)))
+	}
+}
+
+func checkTiDBRowIDDef(r *ParseResult, opts []*ast.TableOption, clusteredCol *ast.ColumnDef) {
+	shardRowID := getTableOption(opts, ast.TableOptionShardRowID)
+	if clusteredCol != nil && shardRowID != nil {
+		r.AddError(TiDBShardRowIDOnClusteredErr)
+	} else if clusteredCol == nil && shardRowID == nil {
+		r.AddError(TiDBRowIDHotspotErr)
+	}
+	autoRandom := clusteredCol != nil && hasColumnOption(clusteredCol, ast.ColumnOptionAutoRandom)
+	checkTiDBPreSplitDef(r, opts, !autoRandom)
+}
+
+func checkTiDBPreSplitDef(r *ParseResult, opts []*ast.TableOption, requireShard bool) {
+	shardRowID := getTableOption(opts, ast.TableOptionShardRowID)
+	preSplit := getTableOption(opts, ast.TableOptionPreSplitRegion)
+	if preSplit == nil || preSplit.UintValue == 0 {
+		return
+	}
+	if shardRowID == nil {
+		if requireShard {
+			r.AddError(TiDBPreSplitWithoutShardErr)
+		}
+	} else if preSplit.UintValue > shardRowID.UintValue {
+		r.AddError(TiDBPreSplitTooLargeErr)
+	}
+}
+
+func checkTiDBPartitionDef(r *ParseResult, part *ast.PartitionOptions) {
+	if part == nil {
+		return
+	}
+	if _, ok := tidbSupportedPartType[part.Tp]; !ok {
+		r.AddError(UnsupportedFeatureErr.Accept("`PARTITION BY "+part.Tp.String()+"`", r.Target, restoreClause(part)))
+	} else if len(part.ColumnNames) > 1 {
+		r.AddError(UnsupportedFeatureErr.Accept("multi-column `RANGE COLUMNS`", r.Target, restoreClause(part)))
+	}
+	if part.Sub != nil {
+		r.AddError(UnsupportedFeatureErr.Accept("`SUBPARTITION`", r.Target, restoreClause(part)))
+	}
+}
+
+// Rule: table option `SHARD_ROW_ID_BITS` and `PRE_SPLIT_REGIONS`
+//       TiDB specific option is ignored by other target (Warning) and must be supported by the TiDB version
+//       `SHARD_ROW_ID_BITS` must be no more than 15
+func checkTableOptTiDBRowIDDef(r *ParseResult, option *ast.TableOption) {
+	feature := featureShardRowID
+	if option.Tp == ast.TableOptionPreSplitRegion {
+		feature = featurePreSplitRegion
+	}
+	if r.Target.Dialect != DialectTiDB {
+		r.AddError(TiDBOptionIgnoredErr.Accept(feature, r.Target))
+		return
+	}
+	if !r.Target.Supports(feature) {
+		r.AddError(UnsupportedFeatureErr.Accept(feature, r.Target, restoreTableOption(option)))
+	}
+	if option.Tp == ast.TableOptionShardRowID && option.UintValue > tidbMaxShardRowIDBits {
+		r.AddError(TiDBShardRowIDTooLargeErr)
+	}
+}
+
+// Rule: column option `AUTO_RANDOM`
+//       TiDB specific option is ignored by other target (Warning) and must be supported by the TiDB version
+func checkColOptAutoRandomDef(r *ParseResult, col *ast.ColumnDef) {
+	if r.Target.Dialect != DialectTiDB {
+		r.AddError(TiDBOptionIgnoredErr.Accept(featureAutoRandom, r.Target))
+	} else if !r.Target.Supports(featureAutoRandom) {
+		r.AddError(UnsupportedFeatureErr.Accept(featureAutoRandom, r.Target, restoreClause(col)))
+	}
+}
+
+// Return the column used as the clustered primary key in TiDB, nil if the primary key is non-clustered
+// Only single integer column primary key is clustered unless `alter-primary-key` is enabled, the primary key is either
+// declared by the column option or the table constraint
+func getTiDBClusteredColumn(target *Target, cols []*ast.ColumnDef, cons []*ast.Constraint) *ast.ColumnDef {
+	if target.AlterPrimaryKey {
+		return nil
+	}
+	for _, col := range cols {
+		if hasColumnOption(col, ast.ColumnOptionPrimaryKey) && col.Tp != nil && col.Tp.EvalType() == types.ETInt {
+			return col
+		}
+	}
+	for _, con := range cons {
+		if con.Tp != ast.ConstraintPrimaryKey || len(con.Keys) != 1 || con.Keys[0].Column == nil {
+			continue
+		}
+		for _, col := range cols {
+			if col.Name.Name.L == con.Keys[0].Column.Name.L && col.Tp != nil && col.Tp.EvalType() == types.ETInt {
+				return col
+			}
+		}
+	}
+	return nil
+}
+
+// Return if the column contains the given option type
+func hasColumnOption(col *ast.ColumnDef, tp ast.ColumnOptionType) bool {
+	for _, option := range col.Options {
+		if option.Tp == tp {
+			return true
+		}
+	}
+	return false
+}
+
+// Return the last table option of the given type, nil if not found
+func getTableOption(opts []*ast.TableOption, tp ast.TableOptionType) *ast.TableOption {
+	var found *ast.TableOption
+	for _, option := range opts {
+		if option.Tp == tp {
+			found = option
+		}
+	}
+	return found
+}
+
+func restoreTableOption(option *ast.TableOption) string {
+	var sb strings.Builder
+	_ = option.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb))
+	return sb.String()
+}
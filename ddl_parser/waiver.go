@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+// Date format of the waiver expiry, the waiver is valid until the end of the day
+const waiverDateFormat = "2006-01-02"
+
+// Waivers: formal exceptions of the rules granted on specific tables, loaded from the file given in `waivers` option
+type Waivers struct {
+	Waivers []*Waiver `json:"waivers"`
+}
+
+// Waiver: finding of `Rule` on `Table` is downgraded to warning and annotated with the waiver until `Expires`
+type Waiver struct {
+	Table    string `json:"table"`
+	Rule     string `json:"rule"`
+	Approver string `json:"approver"`
+	Ticket   string `json:"ticket"`
+	Expires  string `json:"expires"` // e.g. `2026-12-31`
+
+	expires time.Time
+}
+
+// LoadWaivers: load and validate the waivers file, all fields of the waiver are required
+func LoadWaivers(file string) (*Waivers, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	waivers := &Waivers{}
+	if err := json.Unmarshal(content, waivers); err != nil {
+		return nil, err
+	}
+	for i, waiver := range waivers.Waivers {
+		if waiver.Table == "" || waiver.Rule == "" || waiver.Approver == "" || waiver.Ticket == "" || waiver.Expires == "" {
+			return nil, fmt.Errorf("waiver #%d must have `table`, `rule`, `approver`, `ticket` and `expires`", i+1)
+		}
+		if waiver.expires, err = time.Parse(waiverDateFormat, waiver.Expires); err != nil {
+			return nil, fmt.Errorf("waiver #%d has invalid `expires` `%s`, expect format %s", i+1, waiver.Expires,
+				waiverDateFormat)
+		}
+	}
+	return waivers, nil
+}
+
+func (w *Waiver) IsExpired() bool {
+	return !time.Now().Before(w.expires.AddDate(0, 0, 1))
+}
+
+func (w *Waiver) match(r *ParseResult, err *ReturnError) bool {
+	return w.Rule == err.Rule() && (strings.EqualFold(w.Table, r.OldTable) || strings.EqualFold(w.Table, r.NewTable))
+}
+
+// applyWaivers: downgrade the findings waived, report the expired and unmatched waivers
+func applyWaivers(results []*ParseResult, file string) {
+	if len(results) == 0 {
+		return
+	}
+	waivers, err := LoadWaivers(file)
+	if err != nil {
+		results[0].AddError(InvalidOptionErr.Accept(err.Error()))
+		return
+	}
+
+	matched := make(map[*Waiver]struct{})
+	for _, r := range results {
+		var expired []*Waiver
+		for _, err := range r.Error {
+			if err.Level() == DDLMsgTypeIgnore {
+				continue
+			}
+			for _, waiver := range waivers.Waivers {
+				if !waiver.match(r, err) {
+					continue
+				}
+				matched[waiver] = struct{}{}
+				if waiver.IsExpired() {
+					expired = appendWaiver(expired, waiver)
+				} else {
+					err.level = DDLMsgTypeWarning
+					err.waiver = waiver
+				}
+				break
+			}
+		}
+		for _, waiver := range expired {
+			r.AddError(WaiverExpiredErr.Accept(waiver.Rule, waiver.Table, waiver.Ticket, waiver.Expires))
+		}
+	}
+
+	for _, waiver := range waivers.Waivers {
+		if _, ok := matched[waiver]; !ok {
+			results[0].AddError(WaiverUnmatchedErr.Accept(waiver.Rule, waiver.Table, waiver.Ticket))
+		}
+	}
+}
+
+func appendWaiver(waivers []*Waiver, waiver *Waiver) []*Waiver {
+	for _, added := range waivers {
+		if added == waiver {
+			return waivers
+		}
+	}
+	return append(waivers, waiver)
+}
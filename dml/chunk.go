@@ -0,0 +1,148 @@
+package dml
+
+import (
+	"fmt"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/parser/opcode"
+	"strings"
+)
+
+// Default number of primary key values covered by each chunk
+const defaultChunkSize int64 = 1000
+
+// ChunkOptions: primary key of the table is `id` unless given in `PrimaryKeys`, keyed by table name, or known from the
+// schema of the table
+type ChunkOptions struct {
+	Size        int64             `json:"size"`
+	PrimaryKeys map[string]string `json:"primary_keys"` // e.g. `{"example": "example_id"}`, the key must be numeric
+}
+
+// ChunkPlan: run the statement in primary key ranges to avoid long transaction and replication lag
+// Run `BoundaryQuery` to get the minimum and maximum of the key, then run `Statement` with the range
+// `(lower, lower + ChunkSize]` from `lower = minimum - 1` until the range covers the maximum
+type ChunkPlan struct {
+	PrimaryKey    string `json:"primary_key,omitempty"`
+	ChunkSize     int64  `json:"chunk_size,omitempty"`
+	BoundaryQuery string `json:"boundary_query,omitempty"`
+	Statement     string `json:"statement,omitempty"` // Bound to the lower and upper of the range in order
+	Error         string `json:"error,omitempty"`     // Reason the statement cannot be chunked safely
+}
+
+func newChunkError(format string, params ...interface{}) *ChunkPlan {
+	return &ChunkPlan{Error: fmt.Sprintf(format, params...)}
+}
+
+// getUpdateChunk: assigning the primary key moves the row across the ranges, so it may be updated more than once
+func getUpdateChunk(stmt *ast.UpdateStmt, options *ChunkOptions, tables map[string]*TableSchema) *ChunkPlan {
+	table, err := getChunkTable(stmt.TableRefs, stmt.Order, stmt.Limit)
+	if err != nil {
+		return newChunkError(err.Error())
+	}
+	primaryKey, err := options.getPrimaryKey(table, getTableSchema(tables, table))
+	if err != nil {
+		return newChunkError(err.Error())
+	}
+	for _, assignment := range stmt.List {
+		if assignment.Column.Name.L == strings.ToLower(primaryKey) {
+			return newChunkError("primary key `%s` is updated, the row may be updated again in the later chunk",
+				primaryKey)
+		}
+	}
+
+	chunked := *stmt
+	chunked.Where = getChunkWhere(stmt.Where, primaryKey)
+	return options.newChunkPlan(primaryKey, stmt.TableRefs, stmt.Where, restoreClause(&chunked))
+}
+
+func getDeleteChunk(stmt *ast.DeleteStmt, options *ChunkOptions, tables map[string]*TableSchema) *ChunkPlan {
+	if stmt.IsMultiTable {
+		return newChunkError("multiple-table statement cannot be chunked")
+	}
+	table, err := getChunkTable(stmt.TableRefs, stmt.Order, stmt.Limit)
+	if err != nil {
+		return newChunkError(err.Error())
+	}
+	primaryKey, err := options.getPrimaryKey(table, getTableSchema(tables, table))
+	if err != nil {
+		return newChunkError(err.Error())
+	}
+
+	chunked := *stmt
+	chunked.Where = getChunkWhere(stmt.Where, primaryKey)
+	return options.newChunkPlan(primaryKey, stmt.TableRefs, stmt.Where, restoreClause(&chunked))
+}
+
+// getChunkTable: `ORDER BY` and `LIMIT` would apply to each chunk instead of the whole statement
+func getChunkTable(refs *ast.TableRefsClause, order *ast.OrderByClause, limit *ast.Limit) (*ast.TableName, error) {
+	if order != nil || limit != nil {
+		return nil, fmt.Errorf("statement with `ORDER BY` or `LIMIT` cannot be chunked, " +
+			"they would apply to each chunk instead of the whole statement")
+	}
+	if refs == nil || refs.TableRefs == nil || refs.TableRefs.Right != nil {
+		return nil, fmt.Errorf("multiple-table statement cannot be chunked")
+	}
+	if source, ok := refs.TableRefs.Left.(*ast.TableSource); ok {
+		if table, ok := source.Source.(*ast.TableName); ok {
+			return table, nil
+		}
+	}
+	return nil, fmt.Errorf("statement on derived table cannot be chunked")
+}
+
+// getChunkWhere: `(where) AND pk > ? AND pk <= ?`
+func getChunkWhere(where ast.ExprNode, primaryKey string) ast.ExprNode {
+	column := &ast.ColumnNameExpr{Name: &ast.ColumnName{Name: model.NewCIStr(primaryKey)}}
+	chunkWhere := ast.ExprNode(&ast.BinaryOperationExpr{
+		Op: opcode.LogicAnd,
+		L:  &ast.BinaryOperationExpr{Op: opcode.GT, L: column, R: ast.NewParamMarkerExpr(0)},
+		R:  &ast.BinaryOperationExpr{Op: opcode.LE, L: column, R: ast.NewParamMarkerExpr(1)},
+	})
+	if where != nil {
+		chunkWhere = &ast.BinaryOperationExpr{Op: opcode.LogicAnd, L: &ast.ParenthesesExpr{Expr: where}, R: chunkWhere}
+	}
+	return chunkWhere
+}
+
+func (o *ChunkOptions) newChunkPlan(primaryKey string, refs *ast.TableRefsClause, where ast.ExprNode,
+	statement string) *ChunkPlan {
+	column := quoteIdentifier(primaryKey)
+	boundaryQuery := fmt.Sprintf("SELECT MIN(%s), MAX(%s) FROM %s", column, column, restoreClause(refs))
+	if where != nil {
+		boundaryQuery += " WHERE " + restoreClause(where)
+	}
+
+	plan := &ChunkPlan{
+		PrimaryKey:    primaryKey,
+		ChunkSize:     o.Size,
+		BoundaryQuery: boundaryQuery,
+		Statement:     statement,
+	}
+	if plan.ChunkSize <= 0 {
+		plan.ChunkSize = defaultChunkSize
+	}
+	return plan
+}
+
+// getPrimaryKey: match the table name with or without the database name case insensitively
+// The key given with the database name takes precedence, the key given or the primary key of the schema if not given
+// must be a single integer column to compute the ranges, `id` is assumed if the schema is unknown
+func (o *ChunkOptions) getPrimaryKey(table *ast.TableName, schema *TableSchema) (string, error) {
+	for _, tableName := range getTableOptionNames(table) {
+		if primaryKey, ok := o.PrimaryKeys[strings.ToLower(tableName)]; ok {
+			if schema != nil && !schema.isInteger(primaryKey) {
+				return "", fmt.Errorf("key `%s` of table %s is not an integer column, the ranges cannot be computed",
+					primaryKey, restoreClause(table))
+			}
+			return primaryKey, nil
+		}
+	}
+	if schema == nil {
+		return columnID, nil
+	}
+	if len(schema.PrimaryKey) != 1 || !schema.isInteger(schema.PrimaryKey[0]) {
+		return "", fmt.Errorf("table %s has no single integer column primary key, give the key in `primary_keys`",
+			restoreClause(table))
+	}
+	return schema.PrimaryKey[0], nil
+}
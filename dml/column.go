@@ -0,0 +1,213 @@
+package dml
+
+import (
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/model"
+)
+
+// Column written to all columns of the row, e.g. `DELETE` or `INSERT` without column list
+const allColumns = "*"
+
+// ColumnRef: column resolved to the table it belongs to
+// `Table` is empty if the column is not qualified and more than one table is in the scope
+type ColumnRef struct {
+	Table  string `json:"table"`
+	Alias  string `json:"alias,omitempty"` // Qualifier of the column as written if it is an alias of the table
+	Column string `json:"column"`
+}
+
+// tableScope: tables of the `FROM` clause keyed by their alias, or by their name if not aliased
+type tableScope struct {
+	tables map[string]*scopeTable
+	list   []*scopeTable
+	only   *scopeTable // The only table of the scope, used to resolve the column not qualified
+}
+
+type scopeTable struct {
+	name    string // Restored table name, or the alias of the derived table
+	alias   string
+	derived bool
+}
+
+func newTableScope(node ast.ResultSetNode) *tableScope {
+	scope := &tableScope{tables: make(map[string]*scopeTable)}
+	scope.add(node)
+	if len(scope.list) == 1 {
+		scope.only = scope.list[0]
+	}
+	return scope
+}
+
+// add: the tables of the joins and table sources, derived tables are known only by their alias
+func (s *tableScope) add(node ast.ResultSetNode) {
+	switch impl := node.(type) {
+	case *ast.Join:
+		if impl == nil {
+			return
+		}
+		if impl.Left != nil {
+			s.add(impl.Left)
+		}
+		if impl.Right != nil {
+			s.add(impl.Right)
+		}
+	case *ast.TableSource:
+		table := &scopeTable{name: impl.AsName.O}
+		s.list = append(s.list, table)
+		name, ok := impl.Source.(*ast.TableName)
+		if !ok {
+			table.derived = true
+			s.tables[impl.AsName.L] = table
+		} else if impl.AsName.L != "" {
+			table.name, table.alias = restoreClause(name), impl.AsName.O
+			s.tables[impl.AsName.L] = table
+		} else {
+			table.name = restoreClause(name)
+			s.tables[name.Name.L] = table
+			if name.Schema.L != "" {
+				s.tables[name.Schema.L+DBNameSeparator+name.Name.L] = table
+			}
+		}
+	case *ast.TableName:
+		s.add(&ast.TableSource{Source: impl})
+	}
+}
+
+// columnCollector: collect the columns read in the statement with the scopes of the nested `SELECT`
+// The column is resolved from the innermost scope outward, as the correlated subquery refers to the outer tables
+type columnCollector struct {
+	scopes  []*tableScope
+	columns []*ColumnRef
+	exist   map[ColumnRef]struct{}
+}
+
+func newColumnCollector(scope *tableScope) *columnCollector {
+	return &columnCollector{scopes: []*tableScope{scope}, exist: make(map[ColumnRef]struct{})}
+}
+
+func (c *columnCollector) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
+	switch impl := in.(type) {
+	case *ast.SelectStmt:
+		var from ast.ResultSetNode
+		if impl.From != nil && impl.From.TableRefs != nil {
+			from = impl.From.TableRefs
+		}
+		c.scopes = append(c.scopes, newTableScope(from))
+	case *ast.FuncCallExpr:
+		// `VALUES(col)` refers to the value inserted instead of the column read
+		if impl.FnName.L == ast.Values {
+			return in, true
+		}
+	case *ast.ColumnNameExpr:
+		c.add(c.resolve(impl.Name))
+	}
+	return in, false
+}
+
+func (c *columnCollector) Leave(in ast.Node) (out ast.Node, ok bool) {
+	if _, ok := in.(*ast.SelectStmt); ok {
+		c.scopes = c.scopes[:len(c.scopes)-1]
+	}
+	return in, true
+}
+
+func (c *columnCollector) add(column *ColumnRef) {
+	if _, ok := c.exist[*column]; !ok {
+		c.exist[*column] = struct{}{}
+		c.columns = append(c.columns, column)
+	}
+}
+
+// resolve: return the column with the table it belongs to, the qualifier is kept if no table in the scopes matches
+func (c *columnCollector) resolve(name *ast.ColumnName) *ColumnRef {
+	column := &ColumnRef{Column: name.Name.O}
+	qualifier := name.Table.L
+	if name.Schema.L != "" {
+		qualifier = name.Schema.L + DBNameSeparator + qualifier
+	}
+
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		var table *scopeTable
+		if qualifier == "" {
+			table = c.scopes[i].only
+		} else {
+			table = c.scopes[i].tables[qualifier]
+		}
+		if table != nil {
+			column.Table, column.Alias = table.name, table.alias
+			return column
+		}
+		// The column not qualified belongs to the innermost scope with tables, e.g. `SELECT col` without `FROM` refers
+		// to the outer table, and is ambiguous if that scope has more than one table
+		if qualifier == "" && len(c.scopes[i].list) != 0 {
+			return column
+		}
+	}
+	if qualifier != "" {
+		column.Table = restoreClause(&ast.TableName{Schema: name.Schema, Name: name.Table})
+	}
+	return column
+}
+
+// getColumnsRead: columns read in the conditions, the assigned values, the joins and the subqueries
+func getColumnsRead(stmt ast.StmtNode, scope *tableScope) []*ColumnRef {
+	collector := newColumnCollector(scope)
+	stmt.Accept(collector)
+	return collector.columns
+}
+
+// getColumnsWritten: columns assigned, or all columns of the rows inserted without column list or deleted
+func getColumnsWritten(stmt ast.StmtNode, scope *tableScope) []*ColumnRef {
+	collector := newColumnCollector(scope)
+	switch impl := stmt.(type) {
+	case *ast.UpdateStmt:
+		for _, assignment := range impl.List {
+			collector.add(collector.resolve(assignment.Column))
+		}
+	case *ast.DeleteStmt:
+		var tables []*ast.TableName
+		if impl.IsMultiTable && impl.Tables != nil {
+			tables = impl.Tables.Tables
+		} else if scope.only != nil {
+			collector.add(&ColumnRef{Table: scope.only.name, Alias: scope.only.alias, Column: allColumns})
+		}
+		for _, table := range tables {
+			collector.add(collector.resolve(&ast.ColumnName{Schema: table.Schema, Table: table.Name,
+				Name: model.NewCIStr(allColumns)}))
+		}
+	case *ast.InsertStmt:
+		columns := append([]*ast.ColumnName{}, impl.Columns...)
+		for _, assignment := range impl.Setlist {
+			columns = append(columns, assignment.Column)
+		}
+		if len(columns) == 0 {
+			columns = append(columns, &ast.ColumnName{Name: model.NewCIStr(allColumns)})
+		}
+		for _, assignment := range impl.OnDuplicate {
+			columns = append(columns, assignment.Column)
+		}
+		for _, column := range columns {
+			collector.add(collector.resolve(column))
+		}
+	}
+	return collector.columns
+}
+
+// getStatementScope: tables the columns of the statement not in subquery belong to
+func getStatementScope(stmt ast.StmtNode) *tableScope {
+	switch impl := stmt.(type) {
+	case *ast.UpdateStmt:
+		if impl.TableRefs != nil {
+			return newTableScope(impl.TableRefs.TableRefs)
+		}
+	case *ast.DeleteStmt:
+		if impl.TableRefs != nil {
+			return newTableScope(impl.TableRefs.TableRefs)
+		}
+	case *ast.InsertStmt:
+		if impl.Table != nil {
+			return newTableScope(impl.Table.TableRefs)
+		}
+	}
+	return newTableScope(nil)
+}
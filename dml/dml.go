@@ -0,0 +1,353 @@
+// Package dml analyzes DELETE, UPDATE and INSERT statements, shared by the DML parser and the mixed script of the DDL
+// parser
+package dml
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pingcap/parser"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/format"
+	"github.com/pingcap/parser/mysql"
+	_ "github.com/pingcap/tidb/types/parser_driver"
+	"github.com/sidai/parser/fingerprint"
+	"strings"
+)
+
+const (
+	dmlDelete = "DELETE"
+	dmlInsert = "INSERT"
+	dmlUpdate = "UPDATE"
+
+	// Subtype
+	dmlReplace      = "REPLACE"
+	dmlInsertIgnore = "INSERT IGNORE"
+	dmlUpsert       = "INSERT ON DUPLICATE KEY UPDATE"
+	dmlUpdateIgnore = "UPDATE IGNORE"
+	dmlDeleteIgnore = "DELETE IGNORE"
+)
+
+// ParseWithOptions: the statements are chunked in primary key ranges if `chunk` is given
+func ParseWithOptions(sql string, options string) []*ParseResult {
+	opts := &ParseOptions{}
+	if strings.TrimSpace(options) != "" {
+		if err := json.Unmarshal([]byte(options), opts); err != nil {
+			return []*ParseResult{NewParseResult(sql).SetError(fmt.Sprintf("Invalid parse options: %v", err))}
+		}
+	}
+	if err := opts.Normalize(); err != nil {
+		return []*ParseResult{NewParseResult(sql).SetError(fmt.Sprintf("Invalid parse options: %v", err))}
+	}
+
+	results := Parse(sql)
+	for _, r := range results {
+		opts.apply(r)
+	}
+	return results
+}
+
+// Analyze: analyze the statement parsed by the caller, e.g. the DML statement of the mixed script of the DDL parser
+// The options are not applied if nil, otherwise they must be normalized by `Normalize`
+func Analyze(stmt ast.StmtNode, opts *ParseOptions) *ParseResult {
+	result := analyze(stmt)
+	if opts != nil {
+		opts.apply(result)
+	}
+	return result
+}
+
+type ParseOptions struct {
+	Chunk *ChunkOptions `json:"chunk"` // Rewrite `UPDATE` and `DELETE` to run in primary key ranges, see `ChunkPlan`
+
+	// Unique keys other than the primary key by table name, e.g. `{"example": ["uk_email"]}`
+	UniqueKeys map[string][]string `json:"unique_keys"`
+
+	// Schema of the tables known to the caller by lower cased table name, e.g. the tables created earlier in the mixed
+	// script of the DDL parser, the statements on the other tables fall back to the options
+	Tables map[string]*TableSchema `json:"-"`
+}
+
+// TableSchema: keys and column types of the table the statement is checked against
+type TableSchema struct {
+	PrimaryKey     []string // Columns of the primary key, empty if the table has no primary key
+	UniqueKeys     []string // Names of the unique keys other than the primary key
+	IntegerColumns []string // Columns of integer type
+}
+
+// isInteger: match the column name case insensitively
+func (s *TableSchema) isInteger(column string) bool {
+	for _, name := range s.IntegerColumns {
+		if strings.EqualFold(name, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// getTableSchema: schema of the table matched in the same way as the options, nil if unknown
+func getTableSchema(tables map[string]*TableSchema, table *ast.TableName) *TableSchema {
+	for _, tableName := range getTableOptionNames(table) {
+		if schema, ok := tables[strings.ToLower(tableName)]; ok {
+			return schema
+		}
+	}
+	return nil
+}
+
+// Normalize: the table names of the options are matched case insensitively, so they are lower cased on load and the
+// names differ only in case are rejected instead of picking one of them randomly
+func (o *ParseOptions) Normalize() error {
+	if o.Chunk != nil && o.Chunk.PrimaryKeys != nil {
+		primaryKeys := make(map[string]string)
+		for name, primaryKey := range o.Chunk.PrimaryKeys {
+			if _, ok := primaryKeys[strings.ToLower(name)]; ok {
+				return newDuplicateTableOptionErr("chunk.primary_keys", name)
+			}
+			primaryKeys[strings.ToLower(name)] = primaryKey
+		}
+		o.Chunk.PrimaryKeys = primaryKeys
+	}
+	if o.UniqueKeys != nil {
+		uniqueKeys := make(map[string][]string)
+		for name, keys := range o.UniqueKeys {
+			if _, ok := uniqueKeys[strings.ToLower(name)]; ok {
+				return newDuplicateTableOptionErr("unique_keys", name)
+			}
+			uniqueKeys[strings.ToLower(name)] = keys
+		}
+		o.UniqueKeys = uniqueKeys
+	}
+	return nil
+}
+
+func newDuplicateTableOptionErr(option string, name string) error {
+	return fmt.Errorf("table `%s` is given more than once in `%s`, table names are case insensitive",
+		strings.ToLower(name), option)
+}
+
+func (o *ParseOptions) apply(r *ParseResult) {
+	if o.Chunk != nil {
+		switch impl := r.stmt.(type) {
+		case *ast.UpdateStmt:
+			r.Chunk = getUpdateChunk(impl, o.Chunk, o.Tables)
+		case *ast.DeleteStmt:
+			r.Chunk = getDeleteChunk(impl, o.Chunk, o.Tables)
+		}
+	}
+	checkUniqueKeys(r, o.UniqueKeys, o.Tables)
+}
+
+type ParseResult struct {
+	SQL        string     `json:"sql"`
+	TableNames []string   `json:"tables"`
+	DMLType    string     `json:"dml_type"`
+	DMLSubtype string     `json:"dml_subtype"` // e.g. `REPLACE`, `INSERT IGNORE` or `INSERT ON DUPLICATE KEY UPDATE`
+	Priority   string     `json:"priority"`    // `LOW_PRIORITY`, `HIGH_PRIORITY` or `DELAYED`, empty if not given
+	Ignore     bool       `json:"ignore"`
+	ScanClause string     `json:"scan_clause"`
+	Count      int        `json:"insert_count"`
+	Error      string     `json:"error_msg"`
+	Findings   []*Finding `json:"findings"`
+
+	ColumnsWritten []*ColumnRef `json:"columns_written"`
+	ColumnsRead    []*ColumnRef `json:"columns_read"`
+	TargetTables   []string     `json:"target_tables"`  // Tables modified by the statement
+	SourceTables   []string     `json:"source_tables"`  // Tables only read by the statement
+	UpsertColumns  []*ColumnRef `json:"upsert_columns"` // Columns assigned by `ON DUPLICATE KEY UPDATE`
+
+	Rollback *RollbackPlan `json:"rollback,omitempty"` // Not given for statement other than DELETE, UPDATE or INSERT
+	Chunk    *ChunkPlan    `json:"chunk,omitempty"`    // Only given for DELETE and UPDATE if `chunk` option is given
+
+	// Normalized statement with literals replaced, shared by the statements differ only in formatting or literals
+	Fingerprint *fingerprint.Fingerprint `json:"fingerprint"`
+
+	stmt ast.StmtNode
+}
+
+func Parse(sql string) []*ParseResult {
+	var results []*ParseResult
+	p := parser.New()
+
+	stmts, _, err := p.Parse(sql, "", "")
+	if err != nil {
+		result := NewParseResult(sql).SetError(fmt.Sprintf("Syntax error: %+v\n", err))
+		results = append(results, result)
+		return results
+	}
+
+	for _, stmt := range stmts {
+		results = append(results, analyze(stmt))
+	}
+
+	return results
+}
+
+func analyze(stmt ast.StmtNode) *ParseResult {
+	result := NewParseResult(stmt.Text())
+	result.stmt = stmt
+	result.Fingerprint, _ = fingerprint.New(stmt)
+	switch impl := stmt.(type) {
+	case *ast.DeleteStmt:
+		result.SetType(dmlDelete).SetTables(extractTableNameFromExpr(impl.TableRefs)).SetScanClause(restoreClause(
+			&ast.SelectStmt{
+				SelectStmtOpts: &ast.SelectStmtOpts{
+					Distinct:   true,
+					SQLCache:   true,
+					TableHints: impl.TableHints,
+				},
+				Distinct:   true,
+				From:       impl.TableRefs,
+				Where:      impl.Where,
+				Fields:     &ast.FieldList{
+					Fields: []*ast.SelectField{
+						{
+							WildCard:  new(ast.WildCardField),
+						},
+					},
+				},
+				OrderBy:    impl.Order,
+				Limit:      impl.Limit,
+				TableHints: impl.TableHints,
+			}))
+		result.SetSubtype(dmlDelete, dmlDeleteIgnore, impl.IgnoreErr).SetPriority(impl.Priority)
+		result.Rollback = getDeleteRollback(impl)
+		checkDelete(result, impl)
+	case *ast.UpdateStmt:
+		result.SetType(dmlUpdate).SetTables(extractTableNameFromExpr(impl.TableRefs)).SetScanClause(restoreClause(
+			&ast.SelectStmt{
+				SelectStmtOpts: &ast.SelectStmtOpts{
+					Distinct:   true,
+					SQLCache:   true,
+					TableHints: impl.TableHints,
+				},
+				Distinct:   true,
+				From:       impl.TableRefs,
+				Where:      impl.Where,
+				Fields:     &ast.FieldList{
+					Fields: []*ast.SelectField{
+						{
+							WildCard:  new(ast.WildCardField),
+						},
+					},
+				},
+				OrderBy:    impl.Order,
+				Limit:      impl.Limit,
+				TableHints: impl.TableHints,
+			}))
+		result.SetSubtype(dmlUpdate, dmlUpdateIgnore, impl.IgnoreErr).SetPriority(impl.Priority)
+		result.Rollback = getUpdateRollback(impl)
+		checkUpdate(result, impl)
+	case *ast.InsertStmt:
+		result.SetType(dmlInsert).SetTables(extractTableNameFromExpr(impl.Table)).
+			SetCount(len(impl.Lists)).SetScanClause(restoreClause(impl.Select))
+		subtype := getInsertSubtype(impl)
+		result.SetSubtype(subtype, subtype, impl.IgnoreErr).SetPriority(impl.Priority)
+		result.Rollback = getInsertRollback(impl)
+		checkInsert(result, impl)
+	default:
+		result.SetError("statement provided is not a valid DELETE, UPDATE or INSERT query")
+	}
+	if result.DMLType != "" {
+		scope := getStatementScope(stmt)
+		result.ColumnsWritten = getColumnsWritten(stmt, scope)
+		result.ColumnsRead = getColumnsRead(stmt, scope)
+		result.TargetTables = getTargetTables(result.ColumnsWritten, scope)
+		result.SourceTables = getSourceTables(stmt, result.TargetTables)
+		if insert, ok := stmt.(*ast.InsertStmt); ok {
+			result.UpsertColumns = getUpsertColumns(insert, scope)
+		}
+	}
+	return result
+}
+
+func NewParseResult(sql string) *ParseResult {
+	return &ParseResult{SQL: strings.TrimSpace(sql)}
+}
+
+func (p *ParseResult) SetError(err string) *ParseResult {
+	p.Error = err
+	return p
+}
+
+// AddError: add the finding of the DML rule
+func (p *ParseResult) AddError(err error) *ParseResult {
+	p.Findings = append(p.Findings, &Finding{
+		Rule:    DMLErrorRuleMap[err],
+		Level:   DMLErrorMsgTypeMap[err].String(),
+		Message: err.Error(),
+	})
+	return p
+}
+
+func (p *ParseResult) SetTables(tableNames []string) *ParseResult {
+	exist := make(map[string]struct{})
+	for _, name := range tableNames {
+		if _, ok := exist[name]; !ok {
+			p.TableNames = append(p.TableNames, name)
+			exist[name] = struct{}{}
+		}
+	}
+	return p
+}
+
+func (p *ParseResult) SetType(dmlType string) *ParseResult {
+	p.DMLType = dmlType
+	return p
+}
+
+// SetSubtype: the subtype is `ignoreSubtype` if `IGNORE` is given
+func (p *ParseResult) SetSubtype(subtype string, ignoreSubtype string, ignore bool) *ParseResult {
+	p.DMLSubtype, p.Ignore = subtype, ignore
+	if ignore {
+		p.DMLSubtype = ignoreSubtype
+	}
+	return p
+}
+
+func (p *ParseResult) SetPriority(priority mysql.PriorityEnum) *ParseResult {
+	p.Priority = getPriority(priority)
+	return p
+}
+
+func (p *ParseResult) SetCount(count int) *ParseResult {
+	p. Count = count
+	return p
+}
+
+func (p *ParseResult) SetScanClause(clause string) *ParseResult {
+	p.ScanClause = clause
+	return p
+}
+
+func extractTableNameFromExpr(expr *ast.TableRefsClause) []string {
+	if expr == nil {
+		return []string{}
+	}
+	visitor := new(tableNameVisitor)
+	expr.Accept(visitor)
+	return visitor.tableName
+}
+
+func restoreClause(node ast.Node) string {
+	if node == nil {
+		return ""
+	}
+	var sb strings.Builder
+	_ = node.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb))
+	return sb.String()
+}
+
+type tableNameVisitor struct {
+	tableName []string
+}
+
+func (v *tableNameVisitor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
+	if tableNameNode, ok := in.(*ast.TableName); ok {
+		v.tableName = append(v.tableName, restoreClause(tableNameNode))
+	}
+	return in, false
+}
+
+func (v *tableNameVisitor) Leave(in ast.Node) (out ast.Node, ok bool) {
+	return in, true
+}
@@ -0,0 +1,103 @@
+package dml
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Miscellaneous Constant
+const (
+	DBNameSeparator = "."
+	NameSeparator   = "_"
+
+	columnID = "id"
+)
+
+// DML Error
+var (
+	// Where Clause Error
+	NoWhereErr           = errors.New("statement without `WHERE` affects all rows of the table")
+	TautologyWhereErr    = NewCustomError("`WHERE %s` is always true and affects all rows of the table")
+	NonSelectiveWhereErr = NewCustomError("`WHERE %s` likely matches all rows of the table")
+
+	// Limit Error
+	NoLimitErr = errors.New("statement without `LIMIT` may affect a large number of rows, apply it in batches")
+
+	// Conflict Handling Error
+	ReplaceErr = errors.New("`REPLACE` deletes the existing row conflicting on the primary key before inserting, " +
+		"the `DELETE` triggers and foreign key actions are fired")
+	ReplaceUniqueKeyErr = NewCustomError("`REPLACE` into %s deletes every row conflicting on the primary key or " +
+		"unique key %s, one row inserted may delete several rows")
+	IgnoreErr = errors.New("`IGNORE` turns errors into warnings, invalid values are converted and conflicting " +
+		"rows are skipped silently")
+	IgnoreUniqueKeyErr = NewCustomError("`IGNORE` skips the rows conflicting on unique key %[2]s of %[1]s silently")
+)
+
+type DMLMsgType int
+
+const (
+	DMLMsgTypeError DMLMsgType = iota
+	DMLMsgTypeWarning
+)
+
+func (t DMLMsgType) String() string {
+	switch t {
+	case DMLMsgTypeError:
+		return "error"
+	default:
+		return "warning"
+	}
+}
+
+var (
+	// Mapping from error to DMLMsgType to determine what critical level the error should be reported
+	DMLErrorMsgTypeMap = map[error]DMLMsgType{
+		NoWhereErr:           DMLMsgTypeError,
+		TautologyWhereErr:    DMLMsgTypeError,
+		NonSelectiveWhereErr: DMLMsgTypeWarning,
+		NoLimitErr:           DMLMsgTypeWarning,
+		ReplaceErr:           DMLMsgTypeWarning,
+		ReplaceUniqueKeyErr:  DMLMsgTypeError,
+		IgnoreErr:            DMLMsgTypeWarning,
+		IgnoreUniqueKeyErr:   DMLMsgTypeError,
+	}
+
+	// Mapping from error to rule ID to identify which rule the finding is reported by
+	DMLErrorRuleMap = map[error]string{
+		NoWhereErr:           "NoWhereErr",
+		TautologyWhereErr:    "TautologyWhereErr",
+		NonSelectiveWhereErr: "NonSelectiveWhereErr",
+		NoLimitErr:           "NoLimitErr",
+		ReplaceErr:           "ReplaceErr",
+		ReplaceUniqueKeyErr:  "ReplaceUniqueKeyErr",
+		IgnoreErr:            "IgnoreErr",
+		IgnoreUniqueKeyErr:   "IgnoreUniqueKeyErr",
+	}
+)
+
+type CustomError struct {
+	format string
+	params []interface{}
+}
+
+func NewCustomError(format string) *CustomError {
+	return &CustomError{
+		format: format,
+	}
+}
+
+func (c *CustomError) Accept(params ...interface{}) *CustomError {
+	c.params = params
+	return c
+}
+
+func (c *CustomError) Error() string {
+	return fmt.Sprintf(c.format, c.params...)
+}
+
+// Finding: violation of the DML rule
+type Finding struct {
+	Rule    string `json:"rule"`
+	Level   string `json:"level"`
+	Message string `json:"message"`
+}
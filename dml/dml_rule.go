@@ -0,0 +1,255 @@
+package dml
+
+import (
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/opcode"
+	"github.com/pingcap/tidb/types"
+	"strconv"
+	"strings"
+)
+
+// checkUpdate: the rows of `UPDATE` are selected by the `WHERE` and `LIMIT` clauses
+// `MultipleTable` is not set by the parser, multiple-table `UPDATE` is told by the join
+func checkUpdate(r *ParseResult, stmt *ast.UpdateStmt) {
+	checkWhere(r, stmt.Where)
+	if stmt.IgnoreErr {
+		r.AddError(IgnoreErr)
+	}
+	if stmt.TableRefs == nil || stmt.TableRefs.TableRefs == nil || stmt.TableRefs.TableRefs.Right == nil {
+		checkLimit(r, stmt.Where, stmt.Limit)
+	}
+}
+
+// checkDelete: `LIMIT` is not allowed in multiple-table `DELETE`
+func checkDelete(r *ParseResult, stmt *ast.DeleteStmt) {
+	checkWhere(r, stmt.Where)
+	if stmt.IgnoreErr {
+		r.AddError(IgnoreErr)
+	}
+	if !stmt.IsMultiTable {
+		checkLimit(r, stmt.Where, stmt.Limit)
+	}
+}
+
+// checkWhere: the condition must restrict the rows affected, e.g. `WHERE 1 = 1` or `WHERE id > 0` selects all rows
+func checkWhere(r *ParseResult, where ast.ExprNode) {
+	if where == nil {
+		r.AddError(NoWhereErr)
+	} else if isAlwaysTrue(where) {
+		r.AddError(TautologyWhereErr.Accept(restoreClause(where)))
+	} else if matchesAllRows(where) {
+		r.AddError(NonSelectiveWhereErr.Accept(restoreClause(where)))
+	}
+}
+
+// checkLimit: statement looking up rows by `id` affects a bounded number of rows without `LIMIT`
+func checkLimit(r *ParseResult, where ast.ExprNode, limit *ast.Limit) {
+	if limit != nil {
+		return
+	}
+	for _, cond := range splitConjunction(where) {
+		if isLookupByID(cond) {
+			return
+		}
+	}
+	r.AddError(NoLimitErr)
+}
+
+// isAlwaysTrue: the condition holds regardless of the row, e.g. `1`, `TRUE`, `1 = 1`, `'a' = 'a'`, `id = id` or
+// `NOT (1 = 0)`
+func isAlwaysTrue(expr ast.ExprNode) bool {
+	switch impl := expr.(type) {
+	case *ast.ParenthesesExpr:
+		return isAlwaysTrue(impl.Expr)
+	case ast.ValueExpr:
+		value, ok := getConstNumber(impl)
+		return ok && value != 0
+	case *ast.UnaryOperationExpr:
+		return impl.Op == opcode.Not && isAlwaysFalse(impl.V)
+	case *ast.BinaryOperationExpr:
+		switch impl.Op {
+		case opcode.LogicOr:
+			return isAlwaysTrue(impl.L) || isAlwaysTrue(impl.R)
+		case opcode.LogicAnd:
+			return isAlwaysTrue(impl.L) && isAlwaysTrue(impl.R)
+		}
+		left, leftOK := getConstNumber(impl.L)
+		right, rightOK := getConstNumber(impl.R)
+		if leftOK && rightOK {
+			return compareConstNumber(impl.Op, left, right)
+		}
+		switch impl.Op {
+		case opcode.EQ, opcode.GE, opcode.LE, opcode.NullEQ:
+			return (isConst(impl.L) || isColumn(impl.L)) && restoreClause(impl.L) == restoreClause(impl.R)
+		}
+	}
+	return false
+}
+
+// isAlwaysFalse: the condition never holds regardless of the row, e.g. `0`, `FALSE`, `1 = 0` or `NOT 1`
+func isAlwaysFalse(expr ast.ExprNode) bool {
+	switch impl := expr.(type) {
+	case *ast.ParenthesesExpr:
+		return isAlwaysFalse(impl.Expr)
+	case ast.ValueExpr:
+		value, ok := getConstNumber(impl)
+		return ok && value == 0
+	case *ast.UnaryOperationExpr:
+		return impl.Op == opcode.Not && isAlwaysTrue(impl.V)
+	case *ast.BinaryOperationExpr:
+		switch impl.Op {
+		case opcode.LogicOr:
+			return isAlwaysFalse(impl.L) && isAlwaysFalse(impl.R)
+		case opcode.LogicAnd:
+			return isAlwaysFalse(impl.L) || isAlwaysFalse(impl.R)
+		case opcode.EQ, opcode.NullEQ, opcode.NE, opcode.GT, opcode.GE, opcode.LT, opcode.LE:
+			left, leftOK := getConstNumber(impl.L)
+			right, rightOK := getConstNumber(impl.R)
+			return leftOK && rightOK && !compareConstNumber(impl.Op, left, right)
+		}
+	}
+	return false
+}
+
+// matchesAllRows: the condition is always true or only excludes the rows that never exist, e.g. `id > 0`,
+// `id IS NOT NULL` or `name LIKE '%'`
+func matchesAllRows(expr ast.ExprNode) bool {
+	if isAlwaysTrue(expr) {
+		return true
+	}
+	switch impl := expr.(type) {
+	case *ast.ParenthesesExpr:
+		return matchesAllRows(impl.Expr)
+	case *ast.BinaryOperationExpr:
+		switch impl.Op {
+		case opcode.LogicOr:
+			return matchesAllRows(impl.L) || matchesAllRows(impl.R)
+		case opcode.LogicAnd:
+			return matchesAllRows(impl.L) && matchesAllRows(impl.R)
+		case opcode.GT, opcode.GE:
+			value, ok := getConstNumber(impl.R)
+			return isColumn(impl.L) && ok && value <= 0
+		case opcode.LT, opcode.LE:
+			value, ok := getConstNumber(impl.L)
+			return isColumn(impl.R) && ok && value <= 0
+		}
+	case *ast.IsNullExpr:
+		return impl.Not && isColumn(impl.Expr)
+	case *ast.PatternLikeExpr:
+		if value, ok := impl.Pattern.(ast.ValueExpr); ok && !impl.Not && isColumn(impl.Expr) {
+			pattern, ok := value.GetValue().(string)
+			return ok && pattern != "" && strings.Trim(pattern, "%") == ""
+		}
+	}
+	return false
+}
+
+// isLookupByID: `id = 1` or `id IN (1, 2)`
+func isLookupByID(expr ast.ExprNode) bool {
+	switch impl := expr.(type) {
+	case *ast.ParenthesesExpr:
+		return isLookupByID(impl.Expr)
+	case *ast.BinaryOperationExpr:
+		if impl.Op == opcode.EQ {
+			return isIDColumn(impl.L) && isConst(impl.R) || isIDColumn(impl.R) && isConst(impl.L)
+		}
+	case *ast.PatternInExpr:
+		if impl.Not || impl.Sel != nil || !isIDColumn(impl.Expr) {
+			return false
+		}
+		for _, value := range impl.List {
+			if !isConst(value) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// splitConjunction: split the condition by the top level `AND`
+func splitConjunction(expr ast.ExprNode) []ast.ExprNode {
+	switch impl := expr.(type) {
+	case nil:
+		return nil
+	case *ast.ParenthesesExpr:
+		return splitConjunction(impl.Expr)
+	case *ast.BinaryOperationExpr:
+		if impl.Op == opcode.LogicAnd {
+			return append(splitConjunction(impl.L), splitConjunction(impl.R)...)
+		}
+	}
+	return []ast.ExprNode{expr}
+}
+
+func isColumn(expr ast.ExprNode) bool {
+	_, ok := expr.(*ast.ColumnNameExpr)
+	return ok
+}
+
+func isIDColumn(expr ast.ExprNode) bool {
+	column, ok := expr.(*ast.ColumnNameExpr)
+	return ok && column.Name.Name.L == columnID
+}
+
+func isConst(expr ast.ExprNode) bool {
+	switch impl := expr.(type) {
+	case *ast.ParenthesesExpr:
+		return isConst(impl.Expr)
+	case *ast.UnaryOperationExpr:
+		return isConst(impl.V)
+	case ast.ValueExpr:
+		return true
+	}
+	return false
+}
+
+// getConstNumber: numeric value of the literal, string is converted only if it is a number
+func getConstNumber(expr ast.ExprNode) (float64, bool) {
+	switch impl := expr.(type) {
+	case *ast.ParenthesesExpr:
+		return getConstNumber(impl.Expr)
+	case *ast.UnaryOperationExpr:
+		value, ok := getConstNumber(impl.V)
+		switch impl.Op {
+		case opcode.Minus:
+			return -value, ok
+		case opcode.Plus:
+			return value, ok
+		}
+	case ast.ValueExpr:
+		switch value := impl.GetValue().(type) {
+		case int64:
+			return float64(value), true
+		case uint64:
+			return float64(value), true
+		case float64:
+			return value, true
+		case *types.MyDecimal:
+			f, err := value.ToFloat64()
+			return f, err == nil
+		case string:
+			f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			return f, err == nil
+		}
+	}
+	return 0, false
+}
+
+func compareConstNumber(op opcode.Op, left float64, right float64) bool {
+	switch op {
+	case opcode.EQ, opcode.NullEQ:
+		return left == right
+	case opcode.NE:
+		return left != right
+	case opcode.GT:
+		return left > right
+	case opcode.GE:
+		return left >= right
+	case opcode.LT:
+		return left < right
+	case opcode.LE:
+		return left <= right
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+//go:build go1.18
+// +build go1.18
+
+package dml
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// FuzzParse: the statements under testdata/seed are used as the seed corpus, run with `go test -fuzz FuzzParse`
+// Parse must not panic, must be deterministic and the statements must be found in the sql in order
+func FuzzParse(f *testing.F) {
+	seedFiles, err := filepath.Glob("testdata/seed/*.sql")
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, seedFile := range seedFiles {
+		content, err := ioutil.ReadFile(seedFile)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(string(content))
+	}
+
+	f.Fuzz(func(t *testing.T, sql string) {
+		// Parse at the same call site, the syntax error carries the stack trace of the parser
+		var outputs [2][]byte
+		for i := range outputs {
+			output, err := json.Marshal(Parse(sql))
+			if err != nil {
+				t.Fatal(err)
+			}
+			outputs[i] = output
+		}
+		if !bytes.Equal(outputs[0], outputs[1]) {
+			t.Fatalf("non-deterministic output\nfirst:\n%s\nsecond:\n%s", outputs[0], outputs[1])
+		}
+
+		results := Parse(sql)
+		if len(results) == 1 && strings.HasPrefix(results[0].Error, "Syntax error") {
+			return
+		}
+		offset := 0
+		for _, result := range results {
+			index := strings.Index(sql[offset:], result.SQL)
+			if index < 0 {
+				t.Fatalf("statement %q not found after offset %d", result.SQL, offset)
+			}
+			offset += index + len(result.SQL)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package dml
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Regenerate the golden files with `go test -run TestGolden -update`
+var update = flag.Bool("update", false, "update the golden files under testdata/golden")
+
+const (
+	goldenDir       = "testdata/golden"
+	goldenOptionTag = "-- options:"
+)
+
+// goldenResult: the part of the result covered by golden files
+type goldenResult struct {
+	SQL        string     `json:"sql"`
+	TableNames []string   `json:"tables"`
+	DMLType    string     `json:"dml_type"`
+	DMLSubtype string     `json:"dml_subtype"`
+	Priority   string     `json:"priority,omitempty"`
+	Ignore     bool       `json:"ignore,omitempty"`
+	Error      string     `json:"error_msg,omitempty"`
+	Findings   []*Finding `json:"findings"`
+
+	ColumnsWritten []*ColumnRef `json:"columns_written,omitempty"`
+	ColumnsRead    []*ColumnRef `json:"columns_read,omitempty"`
+	TargetTables   []string     `json:"target_tables,omitempty"`
+	SourceTables   []string     `json:"source_tables,omitempty"`
+	UpsertColumns  []*ColumnRef `json:"upsert_columns,omitempty"`
+
+	Rollback *RollbackPlan `json:"rollback,omitempty"`
+	Chunk    *ChunkPlan    `json:"chunk,omitempty"`
+}
+
+// TestGolden: parse each `.sql` case and compare the findings with the `.json` golden file next to it
+// Parse options can be given in the leading comment of the case, e.g. `-- options: {"chunk": {"size": 500}}`
+func TestGolden(t *testing.T) {
+	for _, sqlFile := range getGoldenCases(t) {
+		sqlFile := sqlFile
+		name := strings.TrimSuffix(strings.TrimPrefix(filepath.ToSlash(sqlFile), goldenDir+"/"), ".sql")
+		t.Run(name, func(t *testing.T) {
+			actual := runGoldenCase(t, sqlFile)
+			goldenFile := strings.TrimSuffix(sqlFile, ".sql") + ".json"
+			if *update {
+				if err := ioutil.WriteFile(goldenFile, actual, 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			expected, err := ioutil.ReadFile(goldenFile)
+			if err != nil {
+				t.Fatalf("%s, run with -update to create it", err.Error())
+			}
+			if !bytes.Equal(expected, actual) {
+				t.Errorf("findings mismatch %s, run with -update if the change is expected\nexpected:\n%s\nactual:\n%s",
+					goldenFile, expected, actual)
+			}
+		})
+	}
+}
+
+// TestGoldenRuleCoverage: every rule must be covered by at least one golden file
+func TestGoldenRuleCoverage(t *testing.T) {
+	covered := make(map[string]struct{})
+	for _, sqlFile := range getGoldenCases(t) {
+		var results []*goldenResult
+		if err := json.Unmarshal(runGoldenCase(t, sqlFile), &results); err != nil {
+			t.Fatal(err)
+		}
+		for _, result := range results {
+			for _, finding := range result.Findings {
+				covered[finding.Rule] = struct{}{}
+			}
+		}
+	}
+
+	var missing []string
+	for _, rule := range DMLErrorRuleMap {
+		if _, ok := covered[rule]; !ok {
+			missing = append(missing, rule)
+		}
+	}
+	sort.Strings(missing)
+	if len(missing) != 0 {
+		t.Errorf("rules without golden case: %s", strings.Join(missing, ", "))
+	}
+}
+
+func getGoldenCases(t testing.TB) []string {
+	var cases []string
+	err := filepath.Walk(goldenDir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && filepath.Ext(path) == ".sql" {
+			cases = append(cases, path)
+		}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(cases)
+	return cases
+}
+
+func runGoldenCase(t *testing.T, sqlFile string) []byte {
+	content, err := ioutil.ReadFile(sqlFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sql, options := string(content), ""
+	if strings.HasPrefix(sql, goldenOptionTag) {
+		end := strings.Index(sql, "\n")
+		if end < 0 {
+			end = len(sql)
+		}
+		options = sql[len(goldenOptionTag):end]
+	}
+
+	var results []*goldenResult
+	for _, r := range ParseWithOptions(sql, options) {
+		results = append(results, &goldenResult{
+			SQL:        r.SQL,
+			TableNames: r.TableNames,
+			DMLType:    r.DMLType,
+			DMLSubtype: r.DMLSubtype,
+			Priority:   r.Priority,
+			Ignore:     r.Ignore,
+			Error:      r.Error,
+			Findings:   r.Findings,
+
+			ColumnsWritten: r.ColumnsWritten,
+			ColumnsRead:    r.ColumnsRead,
+			TargetTables:   r.TargetTables,
+			SourceTables:   r.SourceTables,
+			UpsertColumns:  r.UpsertColumns,
+
+			Rollback: r.Rollback,
+			Chunk:    r.Chunk,
+		})
+	}
+
+	actual, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(actual, '\n')
+}
@@ -0,0 +1,103 @@
+package dml
+
+import (
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/mysql"
+	"strings"
+)
+
+// getInsertSubtype: `ON DUPLICATE KEY UPDATE` takes precedence over `IGNORE` as the conflicting rows are updated
+func getInsertSubtype(stmt *ast.InsertStmt) string {
+	switch {
+	case stmt.IsReplace:
+		return dmlReplace
+	case len(stmt.OnDuplicate) != 0:
+		return dmlUpsert
+	case stmt.IgnoreErr:
+		return dmlInsertIgnore
+	}
+	return dmlInsert
+}
+
+// getPriority: empty if the priority is not given
+func getPriority(priority mysql.PriorityEnum) string {
+	if priority == mysql.NoPriority {
+		return ""
+	}
+	return mysql.Priority2Str[priority]
+}
+
+// checkInsert: `REPLACE` deletes the conflicting rows and `IGNORE` hides the errors
+func checkInsert(r *ParseResult, stmt *ast.InsertStmt) {
+	if stmt.IsReplace {
+		r.AddError(ReplaceErr)
+	}
+	if stmt.IgnoreErr {
+		r.AddError(IgnoreErr)
+	}
+}
+
+// getUpsertColumns: columns assigned by `ON DUPLICATE KEY UPDATE`
+func getUpsertColumns(stmt *ast.InsertStmt, scope *tableScope) []*ColumnRef {
+	collector := newColumnCollector(scope)
+	for _, assignment := range stmt.OnDuplicate {
+		collector.add(collector.resolve(assignment.Column))
+	}
+	return collector.columns
+}
+
+// checkUniqueKeys: one row of `REPLACE` may delete several rows conflicting on different unique keys, and `IGNORE`
+// skips the rows conflicting on the unique keys without error
+// The unique keys given in the options take precedence over the unique keys of the schema
+func checkUniqueKeys(r *ParseResult, uniqueKeys map[string][]string, tables map[string]*TableSchema) {
+	var table *ast.TableName
+	var ignore, replace bool
+	switch impl := r.stmt.(type) {
+	case *ast.InsertStmt:
+		table, _, _ = getSingleTable(impl.Table)
+		ignore, replace = impl.IgnoreErr && len(impl.OnDuplicate) == 0, impl.IsReplace
+	case *ast.UpdateStmt:
+		table, _, _ = getSingleTable(impl.TableRefs)
+		ignore = impl.IgnoreErr
+	}
+	if table == nil || !ignore && !replace {
+		return
+	}
+
+	keys := getTableOption(uniqueKeys, table)
+	if schema := getTableSchema(tables, table); len(keys) == 0 && schema != nil {
+		keys = schema.UniqueKeys
+	}
+	if len(keys) == 0 {
+		return
+	}
+	var quoted []string
+	for _, key := range keys {
+		quoted = append(quoted, quoteIdentifier(key))
+	}
+	if replace {
+		r.AddError(ReplaceUniqueKeyErr.Accept(restoreClause(table), strings.Join(quoted, ", ")))
+	}
+	if ignore {
+		r.AddError(IgnoreUniqueKeyErr.Accept(restoreClause(table), strings.Join(quoted, ", ")))
+	}
+}
+
+// getTableOption: option of the table keyed by the table name with or without the database name case insensitively
+// The option given with the database name takes precedence
+func getTableOption(options map[string][]string, table *ast.TableName) []string {
+	for _, tableName := range getTableOptionNames(table) {
+		if option, ok := options[strings.ToLower(tableName)]; ok {
+			return option
+		}
+	}
+	return nil
+}
+
+func getTableOptionNames(table *ast.TableName) []string {
+	var names []string
+	if table.Schema.O != "" {
+		names = append(names, table.Schema.O+DBNameSeparator+table.Name.O)
+	}
+	return append(names, table.Name.O)
+}
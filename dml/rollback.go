@@ -0,0 +1,204 @@
+package dml
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/model"
+	"strings"
+)
+
+// Prefix of the table the before-image is captured in, followed by the table name and the hash of the statement
+const (
+	backupTablePrefix    = "_bak_"
+	backupTableHashBytes = 4
+	maxTableNameLength   = 64
+)
+
+// RollbackPlan: statements run before the DML to capture the before-image and statements reverting the DML
+// Rows are matched by the primary key `id`, the plan is not generated if the rows cannot be matched safely
+type RollbackPlan struct {
+	BeforeImage string   `json:"before_image,omitempty"` // `SELECT` of the rows affected in their original values
+	BackupTable string   `json:"backup_table,omitempty"`
+	Backup      []string `json:"backup"`
+	Restore     []string `json:"restore"`
+	Error       string   `json:"error,omitempty"` // Reason the plan cannot be generated
+}
+
+func newRollbackError(format string, params ...interface{}) *RollbackPlan {
+	return &RollbackPlan{Error: fmt.Sprintf(format, params...)}
+}
+
+// getUpdateRollback: restore the assigned columns from the backup table joined on `id`
+func getUpdateRollback(stmt *ast.UpdateStmt) *RollbackPlan {
+	table, source, err := getSingleTable(stmt.TableRefs)
+	if err != nil {
+		return newRollbackError(err.Error())
+	}
+	if stmt.Limit != nil && !isOrderedByID(stmt.Order) {
+		return newLimitRollbackError()
+	}
+	plan := newBackupPlan(stmt.Text(), table, source, stmt.TableRefs, stmt.Where, stmt.Order, stmt.Limit)
+
+	var assignments []string
+	exist := make(map[string]struct{})
+	for _, assignment := range stmt.List {
+		column := assignment.Column.Name
+		if column.L == columnID {
+			return newRollbackError("primary key `%s` is updated, the rows cannot be matched to restore", columnID)
+		}
+		if _, ok := exist[column.L]; ok {
+			continue
+		}
+		exist[column.L] = struct{}{}
+		assignments = append(assignments, fmt.Sprintf("%s.%s = %s.%s", restoreClause(table),
+			quoteIdentifier(column.O), plan.BackupTable, quoteIdentifier(column.O)))
+	}
+	plan.Restore = append(plan.Restore, fmt.Sprintf("UPDATE %s JOIN %s ON %s.%s = %s.%s SET %s",
+		restoreClause(table), plan.BackupTable, restoreClause(table), quoteIdentifier(columnID), plan.BackupTable,
+		quoteIdentifier(columnID), strings.Join(assignments, ", ")))
+	return plan
+}
+
+// getDeleteRollback: insert the deleted rows back from the backup table
+func getDeleteRollback(stmt *ast.DeleteStmt) *RollbackPlan {
+	if stmt.IsMultiTable {
+		return newRollbackError("rollback of multiple-table statement is not supported")
+	}
+	table, source, err := getSingleTable(stmt.TableRefs)
+	if err != nil {
+		return newRollbackError(err.Error())
+	}
+	if stmt.Limit != nil && !isOrderedByID(stmt.Order) {
+		return newLimitRollbackError()
+	}
+	plan := newBackupPlan(stmt.Text(), table, source, stmt.TableRefs, stmt.Where, stmt.Order, stmt.Limit)
+	plan.Restore = append(plan.Restore, fmt.Sprintf("INSERT INTO %s SELECT * FROM %s", restoreClause(table),
+		plan.BackupTable))
+	return plan
+}
+
+// getInsertRollback: delete the inserted rows by the `id` given in the values, rows existed before are not captured
+// so statement that may overwrite or skip the existing rows is not supported
+func getInsertRollback(stmt *ast.InsertStmt) *RollbackPlan {
+	switch {
+	case stmt.IsReplace:
+		return newRollbackError("`REPLACE` overwrites the existing rows which are not captured")
+	case len(stmt.OnDuplicate) != 0:
+		return newRollbackError("`ON DUPLICATE KEY UPDATE` overwrites the existing rows which are not captured")
+	case stmt.IgnoreErr:
+		return newRollbackError("`INSERT IGNORE` may skip the existing rows which cannot be told from the inserted")
+	case stmt.Select != nil:
+		return newRollbackError("values of `INSERT ... SELECT` are unknown until executed")
+	}
+	table, _, err := getSingleTable(stmt.Table)
+	if err != nil {
+		return newRollbackError(err.Error())
+	}
+
+	var ids []string
+	if len(stmt.Setlist) != 0 {
+		for _, assignment := range stmt.Setlist {
+			if assignment.Column.Name.L == columnID && isConst(assignment.Expr) {
+				ids = append(ids, restoreClause(assignment.Expr))
+			}
+		}
+	} else {
+		for i, column := range stmt.Columns {
+			if column.Name.L != columnID {
+				continue
+			}
+			for _, list := range stmt.Lists {
+				if i < len(list) && isConst(list[i]) {
+					ids = append(ids, restoreClause(list[i]))
+				}
+			}
+		}
+	}
+	rows := len(stmt.Lists)
+	if len(stmt.Setlist) != 0 {
+		rows = 1
+	}
+	if len(ids) == 0 || len(ids) != rows {
+		return newRollbackError("primary key `%s` must be given as literal in every row", columnID)
+	}
+	return &RollbackPlan{
+		Restore: []string{fmt.Sprintf("DELETE FROM %s WHERE %s IN (%s)", restoreClause(table),
+			quoteIdentifier(columnID), strings.Join(ids, ", "))},
+	}
+}
+
+// newBackupPlan: copy the rows selected by the same condition, order and limit to the backup table
+func newBackupPlan(sql string, table *ast.TableName, source *ast.TableSource, refs *ast.TableRefsClause,
+	where ast.ExprNode, order *ast.OrderByClause, limit *ast.Limit) *RollbackPlan {
+	wildcard := &ast.WildCardField{Table: table.Name, Schema: table.Schema}
+	if source.AsName.L != "" {
+		wildcard = &ast.WildCardField{Table: source.AsName}
+	}
+	beforeImage := restoreClause(&ast.SelectStmt{
+		SelectStmtOpts: &ast.SelectStmtOpts{SQLCache: true},
+		From:           refs,
+		Where:          where,
+		Fields:         &ast.FieldList{Fields: []*ast.SelectField{{WildCard: wildcard}}},
+		OrderBy:        order,
+		Limit:          limit,
+	})
+
+	backupTable := restoreClause(&ast.TableName{Schema: table.Schema, Name: model.NewCIStr(getBackupTableName(
+		table.Name.O, sql))})
+	return &RollbackPlan{
+		BeforeImage: beforeImage,
+		BackupTable: backupTable,
+		Backup: []string{
+			fmt.Sprintf("CREATE TABLE %s LIKE %s", backupTable, restoreClause(table)),
+			fmt.Sprintf("INSERT INTO %s %s", backupTable, beforeImage),
+		},
+	}
+}
+
+func newLimitRollbackError() *RollbackPlan {
+	return newRollbackError("`LIMIT` without `ORDER BY` primary key `%s` may back up other rows than the statement "+
+		"changes", columnID)
+}
+
+// isOrderedByID: the rows picked by `LIMIT` are only deterministic if they are ordered by the primary key `id`, the
+// items after it do not change the order
+func isOrderedByID(order *ast.OrderByClause) bool {
+	if order == nil {
+		return false
+	}
+	for _, item := range order.Items {
+		if column, ok := item.Expr.(*ast.ColumnNameExpr); ok && column.Name.Name.L == columnID {
+			return true
+		}
+	}
+	return false
+}
+
+// getSingleTable: return the only table of the statement and the table source giving its alias
+func getSingleTable(refs *ast.TableRefsClause) (*ast.TableName, *ast.TableSource, error) {
+	if refs == nil || refs.TableRefs == nil || refs.TableRefs.Right != nil {
+		return nil, nil, fmt.Errorf("rollback of multiple-table statement is not supported")
+	}
+	if source, ok := refs.TableRefs.Left.(*ast.TableSource); ok {
+		if table, ok := source.Source.(*ast.TableName); ok {
+			return table, source, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("rollback of statement on derived table is not supported")
+}
+
+// getBackupTableName: e.g. `_bak_example_1a2b3c4d`, the hash tells the backups of different statements apart
+func getBackupTableName(tableName string, sql string) string {
+	hash := sha1.Sum([]byte(strings.TrimSpace(sql)))
+	maxLength := maxTableNameLength - len(backupTablePrefix) - len(NameSeparator) - backupTableHashBytes*2
+	if len(tableName) > maxLength {
+		tableName = tableName[:maxLength]
+	}
+	return backupTablePrefix + tableName + NameSeparator + hex.EncodeToString(hash[:backupTableHashBytes])
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
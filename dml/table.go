@@ -0,0 +1,65 @@
+package dml
+
+import (
+	"github.com/pingcap/parser/ast"
+)
+
+// getTargetTables: tables of the columns written, the column not qualified in multiple-table `UPDATE` could belong
+// to any table joined, so all of them are assumed modified
+func getTargetTables(written []*ColumnRef, scope *tableScope) []string {
+	var tables []string
+	exist := make(map[string]struct{})
+	add := func(table string) {
+		if _, ok := exist[table]; !ok {
+			exist[table] = struct{}{}
+			tables = append(tables, table)
+		}
+	}
+
+	for _, column := range written {
+		if column.Table != "" {
+			add(column.Table)
+			continue
+		}
+		for _, table := range scope.list {
+			if !table.derived {
+				add(table.name)
+			}
+		}
+	}
+	return tables
+}
+
+// getSourceTables: tables only read by the statement, in the joins, the subqueries or `INSERT ... SELECT`
+func getSourceTables(stmt ast.StmtNode, targets []string) []string {
+	visitor := &sourceTableVisitor{exist: make(map[string]struct{})}
+	for _, table := range targets {
+		visitor.exist[table] = struct{}{}
+	}
+	stmt.Accept(visitor)
+	return visitor.tableName
+}
+
+type sourceTableVisitor struct {
+	tableName []string
+	exist     map[string]struct{}
+}
+
+// Enter: tables listed in multiple-table `DELETE` are the aliases of the targets instead of the tables read
+func (v *sourceTableVisitor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
+	switch impl := in.(type) {
+	case *ast.DeleteTableList:
+		return in, true
+	case *ast.TableName:
+		name := restoreClause(impl)
+		if _, ok := v.exist[name]; !ok {
+			v.exist[name] = struct{}{}
+			v.tableName = append(v.tableName, name)
+		}
+	}
+	return in, false
+}
+
+func (v *sourceTableVisitor) Leave(in ast.Node) (out ast.Node, ok bool) {
+	return in, true
+}
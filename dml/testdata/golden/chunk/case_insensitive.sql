@@ -0,0 +1,3 @@
+-- options: {"chunk": {"primary_keys": {"Example_Log": "log_id", "SHOP.example_log": "shop_log_id"}}}
+DELETE FROM example_log WHERE created_at < '2020-01-01';
+DELETE FROM Shop.Example_Log WHERE created_at < '2020-01-01';
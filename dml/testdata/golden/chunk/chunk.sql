@@ -0,0 +1,12 @@
+-- options: {"chunk": {"size": 5000, "primary_keys": {"example_log": "log_id", "shop.example_log": "shop_log_id"}}}
+UPDATE example SET status = 1 WHERE status = 0 OR created_at < '2020-01-01';
+DELETE FROM example WHERE created_at < '2020-01-01';
+UPDATE example SET status = 1;
+DELETE FROM example_log WHERE created_at < '2020-01-01';
+DELETE FROM shop.example_log WHERE created_at < '2020-01-01';
+UPDATE example SET status = 1 WHERE status = 0 LIMIT 1000;
+DELETE FROM example WHERE status = 0 ORDER BY created_at;
+UPDATE example SET id = id + 1000000 WHERE status = 0;
+UPDATE example e JOIN example_order o ON e.id = o.example_id SET e.status = o.status;
+DELETE e FROM example e JOIN example_order o ON e.id = o.example_id;
+INSERT INTO example (id, name) VALUES (1, 'a');
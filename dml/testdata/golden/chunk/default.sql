@@ -0,0 +1,2 @@
+-- options: {"chunk": {}}
+DELETE FROM example WHERE status = 0;
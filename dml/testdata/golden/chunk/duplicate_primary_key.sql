@@ -0,0 +1,2 @@
+-- options: {"chunk": {"primary_keys": {"Example": "example_id", "example": "id"}}}
+DELETE FROM example WHERE created_at < '2020-01-01';
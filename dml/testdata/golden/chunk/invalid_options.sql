@@ -0,0 +1,2 @@
+-- options: {"chunk": {"size": "1000"}}
+DELETE FROM example WHERE status = 0;
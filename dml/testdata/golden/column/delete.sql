@@ -0,0 +1,4 @@
+DELETE FROM example WHERE created_at < '2020-01-01' ORDER BY id LIMIT 100;
+DELETE e FROM example e JOIN example_order o ON e.id = o.example_id WHERE o.status = 1;
+DELETE e, o FROM example AS e JOIN example_order AS o ON e.id = o.example_id WHERE e.id = 1;
+DELETE FROM example WHERE id IN (SELECT example_id FROM (SELECT example_id FROM example_order WHERE status = 0) AS d) LIMIT 100;
@@ -0,0 +1,5 @@
+INSERT INTO example (id, name) VALUES (1, 'a');
+INSERT INTO example VALUES (1, 'a');
+INSERT INTO example SET id = 1, name = 'a';
+INSERT INTO example (id, name, hits) VALUES (1, 'a', 1) ON DUPLICATE KEY UPDATE hits = hits + VALUES(hits), name = VALUES(name);
+INSERT INTO example_archive (id, name) SELECT e.id, e.name FROM example e JOIN example_order o ON e.id = o.example_id WHERE o.status = 0;
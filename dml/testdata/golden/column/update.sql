@@ -0,0 +1,7 @@
+UPDATE example SET status = status + 1, remark = CONCAT(name, '-', code) WHERE id = 1;
+UPDATE shop.example e SET e.status = 1 WHERE e.user_id IN (SELECT u.id FROM user u WHERE u.email LIKE '%@example.com') AND e.id = 1;
+UPDATE example e JOIN example_order o ON e.id = o.example_id SET e.status = o.status, o.synced_at = NOW() WHERE o.id = 1 AND amount > 0;
+UPDATE example SET status = 1 WHERE EXISTS (SELECT 1 FROM example_order WHERE example_order.example_id = example.id AND status = 0) AND id = 1;
+UPDATE shop.example SET status = 1 WHERE shop.example.id = 1 AND status = 0;
+UPDATE example SET status = 1 WHERE id IN (SELECT created_at);
+UPDATE example SET status = (SELECT name FROM DUAL) WHERE id = 1;
@@ -0,0 +1,2 @@
+-- options: {"unique_keys": {"Shop.Example": ["uk_code"], "shop.example": ["uk_name"]}}
+REPLACE INTO shop.example (id, code) VALUES (1, 'a');
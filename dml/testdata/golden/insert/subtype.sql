@@ -0,0 +1,7 @@
+INSERT INTO example (id, code) VALUES (1, 'a');
+INSERT LOW_PRIORITY IGNORE INTO example (id, code) VALUES (2, 'b');
+REPLACE INTO example (id, code) VALUES (3, 'c');
+INSERT INTO example (id, code, hits) VALUES (4, 'd', 1) ON DUPLICATE KEY UPDATE hits = hits + VALUES(hits), code = 'd';
+INSERT IGNORE INTO example (id, code) VALUES (5, 'e') ON DUPLICATE KEY UPDATE example.code = 'e';
+UPDATE IGNORE example SET code = 'f' WHERE id = 6 LIMIT 1;
+DELETE LOW_PRIORITY QUICK FROM example WHERE id = 7 LIMIT 1;
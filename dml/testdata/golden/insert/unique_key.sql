@@ -0,0 +1,6 @@
+-- options: {"unique_keys": {"example": ["uk_code"], "shop.example": ["uk_shop_code", "uk_name"]}}
+REPLACE INTO example (id, code) VALUES (1, 'a');
+INSERT IGNORE INTO shop.example (id, code) VALUES (2, 'b');
+INSERT IGNORE INTO example (id, code) VALUES (3, 'c') ON DUPLICATE KEY UPDATE code = 'c';
+UPDATE IGNORE Example SET code = 'd' WHERE id = 4 LIMIT 1;
+INSERT IGNORE INTO other (id, code) VALUES (5, 'e');
@@ -0,0 +1,9 @@
+UPDATE example SET status = 1 WHERE status = 0;
+DELETE FROM example WHERE created_at < '2020-01-01';
+UPDATE example SET status = 1 WHERE status = 0 LIMIT 1000;
+DELETE FROM example WHERE created_at < '2020-01-01' ORDER BY id LIMIT 1000;
+UPDATE example SET status = 1 WHERE id = 1;
+UPDATE example SET status = 1 WHERE status = 0 AND id IN (1, 2, 3);
+DELETE FROM example WHERE 1 = id;
+DELETE FROM example WHERE id IN (SELECT example_id FROM example_order);
+INSERT INTO example (id, status) VALUES (1, 0), (2, 0);
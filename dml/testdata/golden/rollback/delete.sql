@@ -0,0 +1,5 @@
+DELETE FROM example WHERE created_at < '2020-01-01' ORDER BY id LIMIT 1000;
+DELETE FROM shop.example WHERE id IN (1, 2);
+DELETE e FROM example e JOIN example_order o ON e.id = o.example_id WHERE o.status = 1;
+DELETE FROM example WHERE created_at < '2020-01-01' LIMIT 1000;
+DELETE FROM example WHERE created_at < '2020-01-01' ORDER BY created_at LIMIT 1000;
@@ -0,0 +1,8 @@
+INSERT INTO example (id, name) VALUES (1, 'a'), (2, 'b');
+INSERT INTO shop.example SET id = 3, name = 'c';
+INSERT INTO example (name) VALUES ('a');
+INSERT INTO example (id, name) VALUES (1, 'a'), (UUID_SHORT(), 'b');
+INSERT INTO example (id, name) SELECT id, name FROM example_archive;
+INSERT IGNORE INTO example (id, name) VALUES (1, 'a');
+REPLACE INTO example (id, name) VALUES (1, 'a');
+INSERT INTO example (id, name) VALUES (1, 'a') ON DUPLICATE KEY UPDATE name = VALUES(name);
@@ -0,0 +1,6 @@
+UPDATE example SET status = 1, remark = 'fixed' WHERE status = 0 ORDER BY id LIMIT 100;
+UPDATE shop.example e SET e.status = e.status + 1 WHERE e.id = 1;
+UPDATE example SET id = id + 1 WHERE id = 1;
+UPDATE example e JOIN example_order o ON e.id = o.example_id SET e.status = o.status WHERE o.id = 1;
+UPDATE example SET status = 1 WHERE status = 0 LIMIT 100;
+UPDATE example SET status = 1 WHERE status = 0 ORDER BY created_at, id LIMIT 100;
@@ -0,0 +1,11 @@
+UPDATE example SET status = 1 WHERE id = 1;
+UPDATE example e JOIN example_order o ON e.id = o.example_id SET e.status = o.status WHERE o.id = 1;
+UPDATE example e JOIN example_order o ON e.id = o.example_id SET status = 1 WHERE o.id = 1;
+UPDATE example SET status = 1 WHERE user_id IN (SELECT id FROM shop.user WHERE deleted = 1) AND id = 1;
+DELETE FROM example WHERE id = 1;
+DELETE e FROM example e JOIN example_order o ON e.id = o.example_id WHERE o.status = 1;
+DELETE e, o FROM example AS e JOIN example_order AS o ON e.id = o.example_id WHERE e.id = 1;
+DELETE FROM example USING example JOIN example_order ON example.id = example_order.example_id WHERE example_order.status = 1;
+INSERT INTO example_archive (id, name) SELECT id, name FROM example WHERE created_at < '2020-01-01';
+INSERT INTO example (id, name) SELECT id + 1000, name FROM example WHERE id = 1;
+INSERT INTO example (id, name) VALUES (1, (SELECT name FROM example_template WHERE id = 1));
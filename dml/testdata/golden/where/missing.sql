@@ -0,0 +1,4 @@
+UPDATE example SET status = 1;
+DELETE FROM example;
+UPDATE example e JOIN example_order o ON e.id = o.example_id SET e.status = o.status;
+DELETE e FROM example e JOIN example_order o ON e.id = o.example_id;
@@ -0,0 +1,12 @@
+DELETE FROM example WHERE id > 0 LIMIT 100;
+DELETE FROM example WHERE id >= 0 LIMIT 100;
+DELETE FROM example WHERE 0 < id LIMIT 100;
+DELETE FROM example WHERE id > -1 LIMIT 100;
+UPDATE example SET status = 1 WHERE name IS NOT NULL LIMIT 100;
+UPDATE example SET status = 1 WHERE name LIKE '%' LIMIT 100;
+UPDATE example SET status = 1 WHERE id > 0 AND 1 = 1 LIMIT 100;
+UPDATE example SET status = 1 WHERE id > 0 OR status = 1 LIMIT 100;
+UPDATE example SET status = 1 WHERE id > 100 LIMIT 100;
+UPDATE example SET status = 1 WHERE name IS NULL LIMIT 100;
+UPDATE example SET status = 1 WHERE name LIKE 'a%' LIMIT 100;
+UPDATE example SET status = 1 WHERE id > 0 AND status = 1 LIMIT 100;
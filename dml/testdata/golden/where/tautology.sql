@@ -0,0 +1,16 @@
+DELETE FROM example WHERE 1 = 1 LIMIT 100;
+DELETE FROM example WHERE 1 LIMIT 100;
+UPDATE example SET status = 1 WHERE TRUE LIMIT 100;
+UPDATE example SET status = 1 WHERE 'a' = 'a' LIMIT 100;
+UPDATE example SET status = 1 WHERE '1' = 1 LIMIT 100;
+UPDATE example SET status = 1 WHERE 2 > 1 LIMIT 100;
+UPDATE example SET status = 1 WHERE status = 0 OR 1 = 1 LIMIT 100;
+UPDATE example SET status = 1 WHERE status = status LIMIT 100;
+UPDATE example SET status = 1 WHERE (1 = 1) AND (0 <> 1) LIMIT 100;
+UPDATE example SET status = 1 WHERE 1 = 0 LIMIT 100;
+UPDATE example SET status = 1 WHERE status = 0 AND 1 = 1 LIMIT 100;
+DELETE FROM example WHERE NOT (1 = 0) LIMIT 100;
+UPDATE example SET status = 1 WHERE NOT 0 LIMIT 100;
+UPDATE example SET status = 1 WHERE NOT (status = 0 AND 1 = 0) LIMIT 100;
+UPDATE example SET status = 1 WHERE NOT (1 = 1) LIMIT 100;
+UPDATE example SET status = 1 WHERE NOT status = 0 LIMIT 100;
@@ -0,0 +1,2 @@
+DELETE FROM example WHERE created_at < '2020-01-01 00:00:00' ORDER BY id LIMIT 1000;
+DELETE example, example_history FROM example JOIN example_history ON example.id = example_history.example_id WHERE example.status = 'inactive';
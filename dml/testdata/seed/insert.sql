@@ -0,0 +1,4 @@
+INSERT INTO example (id, name, status) VALUES (1, 'first', 'active'), (2, 'second', 'inactive');
+INSERT INTO example_history (example_id, name) SELECT id, name FROM example WHERE status = 'inactive';
+INSERT INTO example (id, name) VALUES (1, 'first') ON DUPLICATE KEY UPDATE name = VALUES(name);
+REPLACE INTO example SET id = 1, name = 'first';
@@ -0,0 +1,2 @@
+UPDATE example SET status = 'inactive', updated_at = NOW() WHERE id IN (1, 2, 3);
+UPDATE /*+ MAX_EXECUTION_TIME(1000) */ example e JOIN example_user u ON e.user_id = u.id SET e.name = u.name WHERE u.deleted = 0 LIMIT 100;
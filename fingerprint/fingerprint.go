@@ -0,0 +1,83 @@
+// Package fingerprint normalizes the statement to group the equivalent statements, shared by the DDL and DML parsers
+package fingerprint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/pingcap/parser"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/format"
+	"github.com/pingcap/parser/opcode"
+	_ "github.com/pingcap/tidb/types/parser_driver"
+	"strings"
+)
+
+// Flags restoring the normalized statement, keywords are in upper case and names are in lower case
+const restoreFlags = format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase | format.RestoreNameBackQuotes |
+	format.RestoreNameLowercase
+
+// Fingerprint: statement restored with literals replaced by `?`, and the SHA-256 of it
+// The lists of `IN` and the rows of `VALUES` are collapsed to one, so the statements differ only in the number of
+// values share the fingerprint, e.g. `DELETE FROM t WHERE id IN (1, 2)` and `delete from T where ID in (3)`
+type Fingerprint struct {
+	Normalized string `json:"normalized"`
+	Hash       string `json:"hash"`
+}
+
+// New: the statement is parsed again from its text, so the given AST is not modified
+func New(stmt ast.StmtNode) (*Fingerprint, error) {
+	normalized, err := parser.New().ParseOneStmt(stmt.Text(), "", "")
+	if err != nil {
+		return nil, err
+	}
+	normalized.Accept(&literalNormalizer{})
+
+	var sb strings.Builder
+	if err := normalized.Restore(format.NewRestoreCtx(restoreFlags, &sb)); err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256([]byte(sb.String()))
+	return &Fingerprint{Normalized: sb.String(), Hash: hex.EncodeToString(hash[:])}, nil
+}
+
+// literalNormalizer: replace the literals on leaving, so the parent sees the replaced children
+type literalNormalizer struct{}
+
+func (n *literalNormalizer) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
+	return in, false
+}
+
+func (n *literalNormalizer) Leave(in ast.Node) (out ast.Node, ok bool) {
+	switch impl := in.(type) {
+	case ast.ValueExpr:
+		return ast.NewParamMarkerExpr(0), true
+	case *ast.UnaryOperationExpr:
+		// signed literal, e.g. `-1`
+		if _, ok := impl.V.(ast.ParamMarkerExpr); ok && (impl.Op == opcode.Minus || impl.Op == opcode.Plus) {
+			return impl.V, true
+		}
+	case *ast.PatternInExpr:
+		if len(impl.List) > 1 && isAllParamMarkers(impl.List) {
+			impl.List = impl.List[:1]
+		}
+	case *ast.InsertStmt:
+		if len(impl.Lists) > 1 {
+			for _, list := range impl.Lists {
+				if len(list) != len(impl.Lists[0]) || !isAllParamMarkers(list) {
+					return in, true
+				}
+			}
+			impl.Lists = impl.Lists[:1]
+		}
+	}
+	return in, true
+}
+
+func isAllParamMarkers(exprs []ast.ExprNode) bool {
+	for _, expr := range exprs {
+		if _, ok := expr.(ast.ParamMarkerExpr); !ok {
+			return false
+		}
+	}
+	return true
+}
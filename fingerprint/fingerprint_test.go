@@ -0,0 +1,87 @@
+package fingerprint
+
+import (
+	"github.com/pingcap/parser"
+	"github.com/pingcap/parser/format"
+	"strings"
+	"testing"
+)
+
+func getFingerprint(t *testing.T, sql string) *Fingerprint {
+	stmt, err := parser.New().ParseOneStmt(sql, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fingerprint, err := New(stmt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fingerprint
+}
+
+func TestFingerprint(t *testing.T) {
+	for _, c := range []struct {
+		sql        string
+		normalized string
+	}{
+		{"UPDATE example SET status = 1 WHERE id = 10", "UPDATE `example` SET `status`=? WHERE `id`=?"},
+		{"DELETE FROM example WHERE id IN (1, 2, 3) AND amount > -1.5",
+			"DELETE FROM `example` WHERE `id` IN (?) AND `amount`>?"},
+		{"INSERT INTO example (id, name) VALUES (1, 'a'), (2, 'b')",
+			"INSERT INTO `example` (`id`,`name`) VALUES (?,?)"},
+		{"INSERT INTO example (id, name) VALUES (1, 'a'), (2, UUID())",
+			"INSERT INTO `example` (`id`,`name`) VALUES (?,?),(?,UUID())"},
+		{"ALTER TABLE Example ADD COLUMN Code VARCHAR(32) NOT NULL DEFAULT 'x'",
+			"ALTER TABLE `example` ADD COLUMN `code` VARCHAR(32) NOT NULL DEFAULT ?"},
+	} {
+		if fingerprint := getFingerprint(t, c.sql); fingerprint.Normalized != c.normalized {
+			t.Errorf("unexpected fingerprint of %s\nexpected: %s\nactual:   %s", c.sql, c.normalized,
+				fingerprint.Normalized)
+		}
+	}
+}
+
+// TestFingerprintStable: the statements differ in formatting, letter case, comments, literals or the number of values
+func TestFingerprintStable(t *testing.T) {
+	for _, group := range [][]string{
+		{
+			"UPDATE example SET status = 1 WHERE id IN (1, 2) AND name = 'a'",
+			"update  `EXAMPLE`\n\tset STATUS=2 /* retry */ where ID in (3) and Name = \"b\"",
+		},
+		{
+			"INSERT INTO example (id, name) VALUES (1, 'a')",
+			"INSERT INTO example(id,name) VALUES (2,'b'),(3,'c'),(-4,'d')",
+		},
+	} {
+		expected := getFingerprint(t, group[0])
+		for _, sql := range group[1:] {
+			if actual := getFingerprint(t, sql); *actual != *expected {
+				t.Errorf("fingerprint of %s\n%+v differs from %s\n%+v", sql, actual, group[0], expected)
+			}
+		}
+	}
+
+	if getFingerprint(t, "DELETE FROM example WHERE id = 1").Hash ==
+		getFingerprint(t, "DELETE FROM example WHERE code = 1").Hash {
+		t.Error("different statements share the fingerprint")
+	}
+}
+
+// TestFingerprintKeepAST: the literals of the statement given must not be replaced
+func TestFingerprintKeepAST(t *testing.T) {
+	stmt, err := parser.New().ParseOneStmt("DELETE FROM example WHERE id = 1", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(stmt); err != nil {
+		t.Fatal(err)
+	}
+
+	var sb strings.Builder
+	if err := stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		t.Fatal(err)
+	}
+	if sb.String() != "DELETE FROM `example` WHERE `id`=1" {
+		t.Fatalf("statement is modified to %s", sb.String())
+	}
+}
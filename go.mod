@@ -1,10 +1,17 @@
 module github.com/sidai/parser
 
-go 1.13
+go 1.18
 
 require (
+	github.com/go-sql-driver/mysql v1.4.1
+	github.com/google/cel-go v0.17.8
+	github.com/pingcap/log v0.0.0-20200117041106-d28c14d3b1cd
 	github.com/pingcap/parser v0.0.0-20200317021010-cd90cc2a7d87
 	github.com/pingcap/tidb v0.0.0-20200326051617-2846f5c5ba1f
+	go.uber.org/zap v1.14.0
 )
 
 replace github.com/pingcap/parser => github.com/sidai/pingcap-parser v0.0.0-20200502205107-d6e10c410d08
+
+// etcd of the embedded TiDB does not build with the grpc required by cel-go
+replace google.golang.org/grpc => google.golang.org/grpc v1.25.1